@@ -0,0 +1,10 @@
+module github.com/blockparty-sh/GoSlp
+
+go 1.23
+
+require (
+	github.com/gcash/bchd v0.19.0
+	golang.org/x/text v0.14.0
+)
+
+require github.com/dchest/siphash v1.2.2 // indirect
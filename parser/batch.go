@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchResult is the outcome of parsing one script in a batch. Index is the
+// position of the script in the input slice.
+type BatchResult struct {
+	Index  int
+	Result *ParseResult
+	Err    error
+}
+
+// ParseSLPBatch parses each script in scripts, returning one BatchResult per
+// script in the same order. A script which fails to parse does not stop the
+// remaining scripts from being parsed.
+func ParseSLPBatch(scripts [][]byte) []BatchResult {
+	results := make([]BatchResult, len(scripts))
+	for i, script := range scripts {
+		r, err := ParseSLP(script)
+		results[i] = BatchResult{
+			Index:  i,
+			Result: r,
+			Err:    err,
+		}
+	}
+	return results
+}
+
+// ParseSLPBatchContext parses scripts like ParseSLPBatch, checking ctx before
+// each script. If ctx is done the results for the scripts parsed so far are
+// returned along with ctx.Err().
+func ParseSLPBatchContext(ctx context.Context, scripts [][]byte) ([]BatchResult, error) {
+	results := make([]BatchResult, 0, len(scripts))
+	for i, script := range scripts {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		r, err := ParseSLP(script)
+		results = append(results, BatchResult{
+			Index:  i,
+			Result: r,
+			Err:    err,
+		})
+	}
+	return results, nil
+}
+
+// ParseSLPBatchParallel parses scripts like ParseSLPBatch, spreading the work
+// across the given number of goroutines. Results are returned in the same
+// order as scripts regardless of the number of workers.
+func ParseSLPBatchParallel(scripts [][]byte, workers int) []BatchResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]BatchResult, len(scripts))
+	size := (len(scripts) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(scripts); start += size {
+		end := start + size
+		if end > len(scripts) {
+			end = len(scripts)
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				r, err := ParseSLP(scripts[i])
+				results[i] = BatchResult{
+					Index:  i,
+					Result: r,
+					Err:    err,
+				}
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return results
+}
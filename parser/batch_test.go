@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+)
+
+// batchScripts creates n scripts, where every third script is not SLP
+func batchScripts(n int) [][]byte {
+	scripts := make([][]byte, n)
+	for i := range scripts {
+		if i%3 == 2 {
+			scripts[i] = []byte{0x6a, 0x04, 'A', 'B', 'C', 0x00, 0x01, 0x01, 0x01, 0x00}
+			continue
+		}
+
+		amount := make([]byte, 8)
+		binary.BigEndian.PutUint64(amount, uint64(i))
+		scripts[i] = buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), amount)
+	}
+	return scripts
+}
+
+func TestParseSLPBatch(t *testing.T) {
+	scripts := [][]byte{
+		buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), make([]byte, 8)),
+		{0x76, 0xa9, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x88, 0xac},
+		buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 31), make([]byte, 8)),
+		buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), make([]byte, 8), make([]byte, 8)),
+	}
+
+	results := ParseSLPBatch(scripts)
+	if len(results) != len(scripts) {
+		t.Fatalf("expected %d results, got %d", len(scripts), len(results))
+	}
+
+	for i, res := range results {
+		if res.Index != i {
+			t.Errorf("expected index %d, got %d", i, res.Index)
+		}
+	}
+
+	if results[0].Err != nil || results[0].Result == nil {
+		t.Errorf("expected valid send, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || results[1].Result != nil {
+		t.Error("expected non-slp script to fail")
+	}
+	if results[2].Err == nil || results[2].Result != nil {
+		t.Error("expected malformed send to fail")
+	}
+	if results[3].Err != nil || len(results[3].Result.Data.(*SlpSend).Amounts) != 2 {
+		t.Errorf("expected valid send after failures, got %v", results[3].Err)
+	}
+}
+
+func TestParseSLPBatchParallelOrder(t *testing.T) {
+	scripts := batchScripts(500)
+	expected := ParseSLPBatch(scripts)
+
+	for _, workers := range []int{0, 1, 2, 7, 64} {
+		results := ParseSLPBatchParallel(scripts, workers)
+		if len(results) != len(scripts) {
+			t.Fatalf("workers %d: expected %d results, got %d", workers, len(scripts), len(results))
+		}
+
+		for i, res := range results {
+			if res.Index != i {
+				t.Fatalf("workers %d: expected index %d, got %d", workers, i, res.Index)
+			}
+			if (res.Err == nil) != (expected[i].Err == nil) {
+				t.Fatalf("workers %d: result %d error mismatch: %v", workers, i, res.Err)
+			}
+			if res.Err == nil && res.Result.Data.(*SlpSend).Amounts[0] != uint64(i) {
+				t.Fatalf("workers %d: result %d out of order", workers, i)
+			}
+		}
+	}
+}
+
+func BenchmarkParseSLPBatch(b *testing.B) {
+	scripts := batchScripts(5000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParseSLPBatch(scripts)
+	}
+}
+
+func BenchmarkParseSLPBatchParallel(b *testing.B) {
+	scripts := batchScripts(5000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParseSLPBatchParallel(scripts, 4)
+	}
+}
+
+// cancelAfterContext reports itself as cancelled after Err has been called n times
+type cancelAfterContext struct {
+	context.Context
+	n int
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func TestParseSLPBatchContext(t *testing.T) {
+	scripts := batchScripts(10)
+
+	results, err := ParseSLPBatchContext(context.Background(), scripts)
+	if err != nil || len(results) != len(scripts) {
+		t.Fatalf("expected %d results, got %d, %v", len(scripts), len(results), err)
+	}
+
+	ctx := &cancelAfterContext{Context: context.Background(), n: 4}
+	results, err = ParseSLPBatchContext(ctx, scripts)
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results before cancellation, got %d", len(results))
+	}
+	for i, res := range results {
+		if res.Index != i {
+			t.Errorf("expected index %d, got %d", i, res.Index)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = ParseSLPBatchContext(cancelled, scripts)
+	if err != context.Canceled || len(results) != 0 {
+		t.Errorf("expected no results for cancelled context, got %d, %v", len(results), err)
+	}
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+)
+
+var (
+	benchGenesisScript = buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("GENESIS"),
+		[]byte("TOK"),
+		[]byte("Token"),
+		[]byte("https://simpleledger.cash"),
+		bytes.Repeat([]byte{0xcd}, 32),
+		[]byte{0x08},
+		[]byte{0x02},
+		[]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8},
+	)
+
+	benchMintScript = buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("MINT"),
+		bytes.Repeat([]byte{0xab}, 32),
+		[]byte{0x02},
+		[]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8},
+	)
+
+	benchSendScript = benchSend(19)
+
+	// P2PKH scriptPubKey, the most common non-SLP output
+	benchNonSLPScript = append(append([]byte{0x76, 0xa9, 0x14}, bytes.Repeat([]byte{0x11}, 20)...), 0x88, 0xac)
+
+	// OP_RETURN for another protocol, rejected at the lokad id
+	benchOtherLokadScript = buildScript(
+		[]byte("SLQ\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		bytes.Repeat([]byte{0xab}, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8},
+	)
+)
+
+// benchSend builds a SEND script with the given number of outputs
+func benchSend(outputs int) []byte {
+	chunks := [][]byte{
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		bytes.Repeat([]byte{0xab}, 32),
+	}
+	for i := 0; i < outputs; i++ {
+		chunks = append(chunks, []byte{0, 0, 0, 0, 0, 0, 0x03, byte(i)})
+	}
+	return buildScript(chunks...)
+}
+
+// parseAllocs returns the average number of allocations made parsing script
+func parseAllocs(t *testing.T, script []byte) float64 {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping allocation check in short mode")
+	}
+
+	return testing.AllocsPerRun(100, func() {
+		ParseSLP(script)
+	})
+}
+
+// TestParseAllocs guards the allocation counts of the parse hot path. The
+// bounds leave room for runtime and compiler changes, apart from scripts
+// which are not OP_RETURN, which must never allocate.
+func TestParseAllocs(t *testing.T) {
+	tests := []struct {
+		name   string
+		script []byte
+		max    float64
+	}{
+		{"genesis", benchGenesisScript, 30},
+		{"mint", benchMintScript, 25},
+		{"send", benchSend(1), 25},
+		{"other lokad", benchOtherLokadScript, 5},
+		{"non-slp", benchNonSLPScript, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if allocs := parseAllocs(t, test.script); allocs > test.max {
+				t.Errorf("expected at most %v allocations, got %v", test.max, allocs)
+			}
+		})
+	}
+}
+
+// TestParseSendAllocsLinear checks that each SEND amount adds at most a
+// constant number of allocations
+func TestParseSendAllocsLinear(t *testing.T) {
+	const perOutput = 2
+
+	one := parseAllocs(t, benchSend(1))
+	max := parseAllocs(t, benchSend(DefaultMaxSendOutputs))
+	if growth := max - one; growth > perOutput*(DefaultMaxSendOutputs-1) {
+		t.Errorf("expected at most %d allocations per output, got %v more for %d outputs",
+			perOutput, growth, DefaultMaxSendOutputs-1)
+	}
+}
+
+func benchmarkParse(b *testing.B, script []byte, valid bool) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(script)))
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseSLP(script); (err == nil) != valid {
+			b.Fatalf("unexpected parse result: %v", err)
+		}
+	}
+}
+
+func BenchmarkParseGenesis(b *testing.B) {
+	benchmarkParse(b, benchGenesisScript, true)
+}
+
+func BenchmarkParseMint(b *testing.B) {
+	benchmarkParse(b, benchMintScript, true)
+}
+
+func BenchmarkParseSend(b *testing.B) {
+	benchmarkParse(b, benchSendScript, true)
+}
+
+func BenchmarkParseNonSLP(b *testing.B) {
+	benchmarkParse(b, benchNonSLPScript, false)
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// canonicalVersion is the version byte starting the CanonicalBytes format
+const canonicalVersion = 0x01
+
+// ErrCanonicalRange is returned by CanonicalBytes for a field whose value
+// does not fit its size in the encoding
+var ErrCanonicalRange = errors.New("value out of range for canonical encoding")
+
+// CanonicalBytes returns a stable binary encoding of the result, suitable for
+// use as a map or database key. Results which are SemanticEqual have the same
+// encoding, whatever the encoding of their scripts.
+//
+// The encoding is not the Bitcoin script. It starts with a version byte, so
+// the format can change without existing keys being misread, followed by the
+// token type as 2 bytes, the transaction type, and the message fields.
+//
+// An error is returned if Data does not match TransactionType, or if the
+// token type, decimals, or mint baton vout does not fit its size, rather
+// than truncating it into the encoding of another result.
+func (r *ParseResult) CanonicalBytes() ([]byte, error) {
+	if err := checkCanonicalRange(r.TokenType, math.MaxUint16, "token_type"); err != nil {
+		return nil, err
+	}
+
+	b := []byte{canonicalVersion}
+	b = binary.BigEndian.AppendUint16(b, uint16(r.TokenType))
+
+	switch r.TransactionType {
+	case "GENESIS":
+		data, ok := r.Data.(*SlpGenesis)
+		if !ok {
+			return nil, errors.New("GENESIS data is not *SlpGenesis")
+		}
+		if err := checkCanonicalRange(data.Decimals, math.MaxUint8, "decimals"); err != nil {
+			return nil, err
+		}
+		if err := checkCanonicalRange(data.MintBatonVout, math.MaxUint8, "mint_baton_vout"); err != nil {
+			return nil, err
+		}
+		b = append(b, 'G')
+		b = appendCanonicalBytes(b, data.Ticker)
+		b = appendCanonicalBytes(b, data.Name)
+		b = appendCanonicalBytes(b, data.DocumentURI)
+		b = appendCanonicalBytes(b, data.DocumentHash)
+		b = append(b, byte(data.Decimals), byte(data.MintBatonVout))
+		b = binary.BigEndian.AppendUint64(b, data.Qty)
+	case "MINT":
+		data, ok := r.Data.(*SlpMint)
+		if !ok {
+			return nil, errors.New("MINT data is not *SlpMint")
+		}
+		if err := checkCanonicalRange(data.MintBatonVout, math.MaxUint8, "mint_baton_vout"); err != nil {
+			return nil, err
+		}
+		b = append(b, 'M')
+		b = appendCanonicalBytes(b, data.TokenID)
+		b = append(b, byte(data.MintBatonVout))
+		b = binary.BigEndian.AppendUint64(b, data.Qty)
+	case "SEND":
+		data, ok := r.Data.(*SlpSend)
+		if !ok {
+			return nil, errors.New("SEND data is not *SlpSend")
+		}
+		b = append(b, 'S')
+		b = appendCanonicalBytes(b, data.TokenID)
+		b = binary.AppendUvarint(b, uint64(len(data.Amounts)))
+		for _, amount := range data.Amounts {
+			b = binary.BigEndian.AppendUint64(b, amount)
+		}
+	default:
+		return nil, errors.New("unknown transaction type")
+	}
+
+	return b, nil
+}
+
+// checkCanonicalRange returns ErrCanonicalRange if v is outside 0 to max
+func checkCanonicalRange(v int, max int, field string) error {
+	if v < 0 || v > max {
+		return fmt.Errorf("%w: %s is %d", ErrCanonicalRange, field, v)
+	}
+
+	return nil
+}
+
+// appendCanonicalBytes appends data prefixed with its length
+func appendCanonicalBytes(b []byte, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
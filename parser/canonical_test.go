@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// mustCanonicalBytes returns the canonical encoding of r, failing the test on
+// error
+func mustCanonicalBytes(t *testing.T, r *ParseResult) []byte {
+	t.Helper()
+
+	b, err := r.CanonicalBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestCanonicalBytes(t *testing.T) {
+	chunks := [][]byte{
+		[]byte("SLP\x00"),
+		{0x01},
+		[]byte("SEND"),
+		bytes.Repeat([]byte{0xab}, 32),
+		{0, 0, 0, 0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 0, 0, 0, 2},
+	}
+
+	a, err := ParseSLP(buildScript(chunks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the same message with a 2 byte token type
+	chunks[1] = []byte{0x00, 0x01}
+	b, err := ParseSLP(buildScript(chunks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aKey, bKey := mustCanonicalBytes(t, a), mustCanonicalBytes(t, b)
+	if !bytes.Equal(aKey, bKey) {
+		t.Errorf("expected equal results to have equal encodings, got %x and %x", aKey, bKey)
+	}
+
+	// the format must stay stable for existing keys
+	expected := "010001" + "53" + "20" + hex.EncodeToString(bytes.Repeat([]byte{0xab}, 32)) +
+		"02" + "0000000000000001" + "0000000000000002"
+	if v := hex.EncodeToString(aKey); v != expected {
+		t.Errorf("expected %s, got %s", expected, v)
+	}
+
+	chunks[5] = []byte{0, 0, 0, 0, 0, 0, 0, 3}
+	c, err := ParseSLP(buildScript(chunks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(aKey, mustCanonicalBytes(t, c)) {
+		t.Error("expected different results to have different encodings")
+	}
+}
+
+func TestCanonicalBytesMessageTypes(t *testing.T) {
+	results := []*ParseResult{
+		{TokenType: 0x01, TransactionType: "GENESIS", Data: &SlpGenesis{Ticker: []byte("A"), Name: []byte("B"), Qty: 1}},
+		{TokenType: 0x01, TransactionType: "GENESIS", Data: &SlpGenesis{Ticker: []byte("AB"), Qty: 1}},
+		{TokenType: 0x01, TransactionType: "MINT", Data: &SlpMint{TokenID: make([]byte, 32), Qty: 1}},
+		{TokenType: 0x81, TransactionType: "MINT", Data: &SlpMint{TokenID: make([]byte, 32), Qty: 1}},
+		{TokenType: 0x01, TransactionType: "SEND", Data: &SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{1}}},
+	}
+
+	seen := make(map[string]int)
+	for i, r := range results {
+		key := string(mustCanonicalBytes(t, r))
+		if j, ok := seen[key]; ok {
+			t.Errorf("results %d and %d have the same encoding %x", j, i, key)
+		}
+		seen[key] = i
+
+		if key != string(mustCanonicalBytes(t, r.Clone())) {
+			t.Errorf("result %d: expected clone to have the same encoding", i)
+		}
+	}
+
+	if _, err := (&ParseResult{}).CanonicalBytes(); err == nil {
+		t.Error("expected error for a result without a message")
+	}
+}
+
+func TestCanonicalBytesInvalid(t *testing.T) {
+	tokenID := make([]byte, 32)
+	tests := []struct {
+		name   string
+		result *ParseResult
+		err    error
+	}{
+		{"mint baton vout 256", &ParseResult{TokenType: 0x01, TransactionType: "MINT",
+			Data: &SlpMint{TokenID: tokenID, MintBatonVout: 256}}, ErrCanonicalRange},
+		{"genesis baton vout 256", &ParseResult{TokenType: 0x01, TransactionType: "GENESIS",
+			Data: &SlpGenesis{MintBatonVout: 256}}, ErrCanonicalRange},
+		{"negative decimals", &ParseResult{TokenType: 0x01, TransactionType: "GENESIS",
+			Data: &SlpGenesis{Decimals: -1}}, ErrCanonicalRange},
+		{"3 byte token type", &ParseResult{TokenType: 0x10001, TransactionType: "SEND",
+			Data: &SlpSend{TokenID: tokenID, Amounts: []uint64{1}}}, ErrCanonicalRange},
+		{"type mismatch", &ParseResult{TokenType: 0x01, TransactionType: "SEND",
+			Data: &SlpMint{TokenID: tokenID}}, nil},
+	}
+
+	for _, test := range tests {
+		_, err := test.result.CanonicalBytes()
+		if err == nil || (test.err != nil && !errors.Is(err, test.err)) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package parser
+
+import "errors"
+
+// ErrorClass is a coarse category of parse error for monitoring
+type ErrorClass int
+
+const (
+	// ErrorClassNone is the class of a nil error
+	ErrorClassNone ErrorClass = iota
+	// ErrorClassNotSLP is a script which is not an SLP message at all
+	ErrorClassNotSLP
+	// ErrorClassMalformed is an SLP message which breaks the SLP rules
+	ErrorClassMalformed
+	// ErrorClassPolicy is an SLP message rejected by a check which can be
+	// configured by ParseOptions or EncodeOptions
+	ErrorClassPolicy
+)
+
+// String returns the name of the class
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassNone:
+		return "none"
+	case ErrorClassNotSLP:
+		return "not-slp"
+	case ErrorClassMalformed:
+		return "malformed"
+	case ErrorClassPolicy:
+		return "policy"
+	}
+
+	return "unknown"
+}
+
+var notSlpErrors = []error{
+	ErrEmptyScript,
+	ErrTooSmall,
+	ErrNotOpReturn,
+	ErrEmptyFirstPush,
+	ErrNotSLP,
+}
+
+var policyErrors = []error{
+	ErrTokenTypeNotAllowed,
+	ErrNonMinimalPush,
+	ErrInvalidUtf8,
+	ErrTooManyOutputs,
+	ErrRepeatedLokad,
+	ErrFieldTooLong,
+	ErrScriptTooLarge,
+}
+
+// ClassifyError returns the class of an error returned by the parser, using
+// errors.Is against the package's errors. Any other non-nil error is
+// ErrorClassMalformed.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	for _, target := range notSlpErrors {
+		if errors.Is(err, target) {
+			return ErrorClassNotSLP
+		}
+	}
+
+	for _, target := range policyErrors {
+		if errors.Is(err, target) {
+			return ErrorClassPolicy
+		}
+	}
+
+	return ErrorClassMalformed
+}
@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected ErrorClass
+	}{
+		{nil, ErrorClassNone},
+		{ErrNotSLP, ErrorClassNotSLP},
+		{ErrNotOpReturn, ErrorClassNotSLP},
+		{ErrTooSmall, ErrorClassNotSLP},
+		{ErrTooManyOutputs, ErrorClassPolicy},
+		{ErrTokenTypeNotAllowed, ErrorClassPolicy},
+		{fmt.Errorf("wrapped: %w", ErrNonMinimalPush), ErrorClassPolicy},
+		{ErrDocumentHashSize, ErrorClassMalformed},
+		{errors.New("other"), ErrorClassMalformed},
+	}
+
+	for _, test := range tests {
+		if v := ClassifyError(test.err); v != test.expected {
+			t.Errorf("ClassifyError(%v) = %s, expected %s", test.err, v, test.expected)
+		}
+	}
+}
+
+func TestClassifyParseErrors(t *testing.T) {
+	amount := []byte{0, 0, 0, 0, 0, 0, 0, 1}
+	amounts := make([][]byte, 20)
+	for i := range amounts {
+		amounts[i] = amount
+	}
+
+	tests := []struct {
+		name     string
+		script   []byte
+		expected ErrorClass
+	}{
+		{"not slp", buildScript([]byte("ABC\x00"), []byte{0x01}, []byte("SEND")), ErrorClassNotSLP},
+		{"malformed", buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), amount[1:]), ErrorClassMalformed},
+		{"too many outputs", buildScript(append([][]byte{[]byte("SLP\x00"), {0x01}, []byte("SEND"), make([]byte, 32)}, amounts...)...), ErrorClassPolicy},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSLP(test.script)
+		if v := ClassifyError(err); v != test.expected {
+			t.Errorf("%s: expected %s, got %s (%v)", test.name, test.expected, v, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package parser
+
+// Clone returns a deep copy of the genesis message
+func (g *SlpGenesis) Clone() SlpGenesis {
+	c := *g
+	c.Ticker = cloneBytes(g.Ticker)
+	c.Name = cloneBytes(g.Name)
+	c.DocumentURI = cloneBytes(g.DocumentURI)
+	c.DocumentHash = cloneBytes(g.DocumentHash)
+	return c
+}
+
+// Clone returns a deep copy of the mint message
+func (m *SlpMint) Clone() SlpMint {
+	c := *m
+	c.TokenID = cloneBytes(m.TokenID)
+	return c
+}
+
+// Clone returns a deep copy of the send message
+func (s *SlpSend) Clone() SlpSend {
+	c := *s
+	c.TokenID = cloneBytes(s.TokenID)
+	if s.Amounts != nil {
+		c.Amounts = make([]uint64, len(s.Amounts))
+		copy(c.Amounts, s.Amounts)
+	}
+	return c
+}
+
+// Clone returns a deep copy of the parse result, including its message
+func (r *ParseResult) Clone() *ParseResult {
+	c := *r
+	c.TokenTypeBytes = cloneBytes(r.TokenTypeBytes)
+	c.TrailingBytes = cloneBytes(r.TrailingBytes)
+	switch data := r.Data.(type) {
+	case *SlpGenesis:
+		g := data.Clone()
+		c.Data = &g
+	case *SlpMint:
+		m := data.Clone()
+		c.Data = &m
+	case *SlpSend:
+		s := data.Clone()
+		c.Data = &s
+	}
+	return &c
+}
+
+// cloneBytes returns a copy of b, preserving whether b is nil
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCloneGenesis(t *testing.T) {
+	g := SlpGenesis{
+		Ticker:        []byte("TOK"),
+		Name:          []byte("Token"),
+		DocumentURI:   []byte("https://simpleledger.cash"),
+		DocumentHash:  make([]byte, 32),
+		Decimals:      2,
+		MintBatonVout: 2,
+		Qty:           1000,
+	}
+
+	c := g.Clone()
+	if !c.Equal(g) {
+		t.Fatal("expected clone to equal original")
+	}
+
+	c.Ticker[0] = 'X'
+	c.Name[0] = 'X'
+	c.DocumentURI[0] = 'X'
+	c.DocumentHash[0] = 0xff
+	if !bytes.Equal(g.Ticker, []byte("TOK")) ||
+		!bytes.Equal(g.Name, []byte("Token")) ||
+		!bytes.Equal(g.DocumentURI, []byte("https://simpleledger.cash")) ||
+		g.DocumentHash[0] != 0 {
+		t.Error("modifying clone changed the original")
+	}
+
+	if (&SlpGenesis{}).Clone().Ticker != nil {
+		t.Error("expected nil fields to remain nil")
+	}
+}
+
+func TestCloneParseResult(t *testing.T) {
+	r := &ParseResult{
+		TokenType:       0x01,
+		TransactionType: "SEND",
+		Data: &SlpSend{
+			TokenID: make([]byte, 32),
+			Amounts: []uint64{1, 2},
+		},
+	}
+
+	c := r.Clone()
+	s := c.Data.(*SlpSend)
+	s.TokenID[0] = 0xff
+	s.Amounts[0] = 100
+
+	orig := r.Data.(*SlpSend)
+	if orig.TokenID[0] != 0 || orig.Amounts[0] != 1 {
+		t.Error("modifying cloned send changed the original")
+	}
+
+	r = &ParseResult{
+		TokenType:       0x01,
+		TransactionType: "MINT",
+		Data:            &SlpMint{TokenID: make([]byte, 32), Qty: 1},
+	}
+
+	c = r.Clone()
+	m := c.Data.(*SlpMint)
+	m.TokenID[0] = 0xff
+	if r.Data.(*SlpMint).TokenID[0] != 0 {
+		t.Error("modifying cloned mint changed the original")
+	}
+}
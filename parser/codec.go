@@ -0,0 +1,198 @@
+package parser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ScriptCodec converts between an OP_RETURN scriptPubKey and the pushdata
+// chunks it carries, separating the script framing from the SLP message
+// format. The parser only sees the decoded chunks, so all framing checks,
+// such as the OP_RETURN prefix and minimal pushes, belong to the codec.
+type ScriptCodec interface {
+	// DecodeChunks returns the data pushed by an OP_RETURN script. If an
+	// error is returned, the chunks decoded before the error are returned
+	// with it. Bytes following the last chunk must be reported with a
+	// TrailingDataError, which ParseOptions.AllowTrailingData accepts.
+	DecodeChunks(script []byte) ([][]byte, error)
+
+	// FirstChunk returns only the first chunk pushed by an OP_RETURN script,
+	// which lets the parser reject non-SLP scripts before the rest is
+	// decoded. The chunk may reference script. If an error is returned the
+	// parser calls DecodeChunks to report it.
+	FirstChunk(script []byte) ([]byte, error)
+
+	// EncodeChunks creates an OP_RETURN script pushing each chunk
+	EncodeChunks(chunks [][]byte) ([]byte, error)
+}
+
+// BCHCodec is the ScriptCodec for Bitcoin Cash scripts, used by default.
+// Empty chunks are pushed as OP_PUSHDATA1 with a length of 0, since SLP does
+// not allow OP_0.
+type BCHCodec struct {
+	// RequireMinimalPush rejects chunks which could have been pushed with a
+	// smaller opcode when decoding
+	RequireMinimalPush bool
+}
+
+// DecodeChunks returns the data pushed by an OP_RETURN script. Scripts which
+// are empty, too small to hold an SLP message, not an OP_RETURN, or start
+// with an empty push are rejected before any chunks are decoded.
+func (c BCHCodec) DecodeChunks(script []byte) ([][]byte, error) {
+	if err := checkScriptPrefix(script); err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]byte, 0)
+	it := 1
+	for it < len(script) {
+		data, next, ok, err := c.decodePush(script, it, len(chunks))
+		if err != nil {
+			return chunks, err
+		}
+		if !ok {
+			break
+		}
+
+		buf := make([]byte, len(data))
+		copy(buf, data)
+		chunks = append(chunks, buf)
+		it = next
+	}
+
+	if it != len(script) {
+		return chunks, &TrailingDataError{Data: script[it:]}
+	}
+
+	return chunks, nil
+}
+
+// FirstChunk returns the first chunk pushed by an OP_RETURN script, applying
+// the same checks as DecodeChunks. The returned chunk references script.
+func (c BCHCodec) FirstChunk(script []byte) ([]byte, error) {
+	if err := checkScriptPrefix(script); err != nil {
+		return nil, err
+	}
+
+	data, _, ok, err := c.decodePush(script, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &TrailingDataError{Data: script[1:]}
+	}
+
+	return data, nil
+}
+
+// decodePush reads the push at script[it] for the chunk at chunkIndex,
+// returning the data and the index following it. ok is false if script[it]
+// is not a pushdata opcode allowed in SLP.
+func (c BCHCodec) decodePush(script []byte, it int, chunkIndex int) (data []byte, next int, ok bool, err error) {
+	opcode, dataLen, dataStart, ok := readPushdataHeader(script, it)
+	if !ok {
+		if lengthBytes := pushdataLengthBytes(opcode); lengthBytes > 0 {
+			return nil, it, false, pushdataOverrun(chunkIndex, lengthBytes, len(script)-dataStart, "length bytes")
+		}
+		return nil, it, false, nil
+	}
+
+	if dataLen > len(script)-dataStart {
+		return nil, it, false, pushdataOverrun(chunkIndex, dataLen, len(script)-dataStart, "bytes")
+	}
+
+	if c.RequireMinimalPush {
+		minimal, _, _ := MinimalPushOpcode(dataLen)
+		if err := chunkCheckReason(opcode != int(minimal), chunkIndex, "pushdata", ErrNonMinimalPush); err != nil {
+			return nil, it, false, err
+		}
+	}
+
+	return script[dataStart : dataStart+dataLen], dataStart + dataLen, true, nil
+}
+
+// EncodeChunks creates an OP_RETURN script pushing each chunk using the
+// smallest possible opcode
+func (c BCHCodec) EncodeChunks(chunks [][]byte) ([]byte, error) {
+	for _, chunk := range chunks {
+		if _, _, err := MinimalPushOpcode(len(chunk)); err != nil {
+			return nil, err
+		}
+	}
+
+	return encodeChunks(chunks...), nil
+}
+
+// ErrPushdataOverrun is returned when a push claims more bytes than remain in
+// the script
+var ErrPushdataOverrun = errors.New("pushdata length exceeds remaining bytes")
+
+func pushdataOverrun(chunkIndex int, claimed int, available int, what string) error {
+	return chunkCheckReason(true, chunkIndex, "pushdata",
+		fmt.Errorf("%w: claimed %d %s, %d available", ErrPushdataOverrun, claimed, what, available))
+}
+
+// pushdataLengthBytes returns the number of length bytes following an
+// OP_PUSHDATA opcode, or 0 for any other opcode
+func pushdataLengthBytes(opcode int) int {
+	switch opcode {
+	case 0x4c:
+		return 1
+	case 0x4d:
+		return 2
+	case 0x4e:
+		return 4
+	}
+
+	return 0
+}
+
+// TrailingDataError is returned by a ScriptCodec when the script has bytes
+// following its last push which could not be decoded as a chunk
+type TrailingDataError struct {
+	Data []byte
+}
+
+func (e *TrailingDataError) Error() string {
+	return "trailing data"
+}
+
+// readPushdataHeader reads the pushdata opcode at script[it] and any length
+// prefix following it, returning the opcode, the length of the data, and the
+// index where the data starts. ok is false if script[it] is not a pushdata
+// opcode allowed in SLP or its length prefix is truncated. The data itself
+// may extend past the end of script.
+func readPushdataHeader(script []byte, it int) (opcode int, dataLen int, dataStart int, ok bool) {
+	if it >= len(script) {
+		return 0, 0, it, false
+	}
+
+	opcode = int(script[it])
+	it++
+
+	switch {
+	case opcode > 0x00 && opcode < 0x4c:
+		return opcode, opcode, it, true
+	case opcode == 0x4c && it+1 <= len(script):
+		return opcode, int(script[it]), it + 1, true
+	case opcode == 0x4d && it+2 <= len(script):
+		return opcode, int(binary.LittleEndian.Uint16(script[it:])), it + 2, true
+	case opcode == 0x4e && it+4 <= len(script):
+		return opcode, int(binary.LittleEndian.Uint32(script[it:])), it + 4, true
+	}
+
+	return opcode, 0, it, false
+}
+
+// readPushdata reads the pushdata starting at script[it], returning the data
+// and the index following it. ok is false if script[it] is not a pushdata
+// opcode allowed in SLP or the data extends past the end of script.
+func readPushdata(script []byte, it int) (data []byte, next int, ok bool) {
+	_, dataLen, dataStart, ok := readPushdataHeader(script, it)
+	if !ok || dataLen > len(script)-dataStart {
+		return nil, it, false
+	}
+
+	return script[dataStart : dataStart+dataLen], dataStart + dataLen, true
+}
@@ -0,0 +1,236 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// codecScript returns an OP_RETURN script whose first push is codecPrefix,
+// making it large enough for BCHCodec, followed by rest
+func codecScript(rest ...byte) []byte {
+	return append(append([]byte{0x6a, byte(len(codecPrefix))}, codecPrefix...), rest...)
+}
+
+var codecPrefix = bytes.Repeat([]byte{0x11}, 8)
+
+func TestBCHCodecDecodeChunks(t *testing.T) {
+	var codec ScriptCodec = BCHCodec{}
+
+	tests := []struct {
+		name   string
+		script []byte
+		chunks [][]byte
+		err    error
+	}{
+		{"direct push", codecScript(0x02, 0xaa, 0xbb), [][]byte{codecPrefix, {0xaa, 0xbb}}, nil},
+		{"empty pushdata1", codecScript(0x4c, 0x00), [][]byte{codecPrefix, {}}, nil},
+		{"pushdata2", codecScript(0x4d, 0x01, 0x00, 0xcc), [][]byte{codecPrefix, {0xcc}}, nil},
+		{"pushdata4", codecScript(0x4e, 0x01, 0x00, 0x00, 0x00, 0xdd), [][]byte{codecPrefix, {0xdd}}, nil},
+		{"multiple", codecScript(0x01, 0x01, 0x4c, 0x00, 0x01, 0x02), [][]byte{codecPrefix, {0x01}, {}, {0x02}}, nil},
+		{"too small", []byte{0x6a, 0x01, 0x01}, nil, ErrTooSmall},
+		{"not op_return", append([]byte{0x51}, codecScript()[1:]...), nil, ErrNotOpReturn},
+		{"empty script", []byte{}, nil, ErrEmptyScript},
+		{"empty first push", append([]byte{0x6a, 0x4c, 0x00}, codecScript()[1:]...), nil, ErrEmptyFirstPush},
+		{"truncated data", codecScript(0x01, 0x01, 0x03, 0xaa), [][]byte{codecPrefix, {0x01}}, ErrPushdataOverrun},
+		{"truncated length", codecScript(0x4d, 0x01), [][]byte{codecPrefix}, ErrPushdataOverrun},
+	}
+
+	for _, test := range tests {
+		chunks, err := codec.DecodeChunks(test.script)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.err, err)
+		}
+		if !reflect.DeepEqual(chunks, test.chunks) {
+			t.Errorf("%s: expected chunks %x, got %x", test.name, test.chunks, chunks)
+		}
+	}
+}
+
+func TestBCHCodecFirstChunk(t *testing.T) {
+	tests := []struct {
+		name   string
+		script []byte
+		chunk  []byte
+		err    error
+	}{
+		{"direct push", codecScript(0x02, 0xaa, 0xbb), codecPrefix, nil},
+		{"rest not decoded", codecScript(0x4d, 0x01), codecPrefix, nil},
+		{"not op_return", append([]byte{0x51}, codecScript()[1:]...), nil, ErrNotOpReturn},
+		{"truncated first push", append([]byte{0x6a, 0x20}, codecPrefix...), nil, ErrPushdataOverrun},
+	}
+
+	for _, test := range tests {
+		chunk, err := BCHCodec{}.FirstChunk(test.script)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.err, err)
+		}
+		if !bytes.Equal(chunk, test.chunk) {
+			t.Errorf("%s: expected chunk %x, got %x", test.name, test.chunk, chunk)
+		}
+	}
+
+	script := append([]byte{0x6a, 0x4c, byte(len(codecPrefix))}, codecPrefix...)
+	if _, err := (BCHCodec{RequireMinimalPush: true}).FirstChunk(script); !errors.Is(err, ErrNonMinimalPush) {
+		t.Errorf("expected ErrNonMinimalPush, got %v", err)
+	}
+}
+
+func TestBCHCodecDecodeChunksMinimalPush(t *testing.T) {
+	script := codecScript(0x01, 0x01, 0x4c, 0x01, 0x02)
+
+	if _, err := (BCHCodec{}).DecodeChunks(script); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	chunks, err := BCHCodec{RequireMinimalPush: true}.DecodeChunks(script)
+	if !errors.Is(err, ErrNonMinimalPush) {
+		t.Fatalf("expected ErrNonMinimalPush, got %v", err)
+	}
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.ChunkIndex != 2 {
+		t.Errorf("expected ParseError at chunk 2, got %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Errorf("expected 2 chunks decoded before the error, got %d", len(chunks))
+	}
+}
+
+func TestBCHCodecRoundTrip(t *testing.T) {
+	codec := BCHCodec{RequireMinimalPush: true}
+	chunks := [][]byte{
+		LokadID(),
+		{},
+		{0x01},
+		bytes.Repeat([]byte{0x02}, 75),
+		bytes.Repeat([]byte{0x03}, 76),
+		bytes.Repeat([]byte{0x04}, 256),
+	}
+
+	script, err := codec.EncodeChunks(chunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := codec.DecodeChunks(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, chunks) {
+		t.Errorf("round trip mismatch: expected %x, got %x", chunks, decoded)
+	}
+}
+
+type upperCaseLokadCodec struct {
+	BCHCodec
+}
+
+func (c upperCaseLokadCodec) DecodeChunks(script []byte) ([][]byte, error) {
+	chunks, err := c.BCHCodec.DecodeChunks(script)
+	if len(chunks) > 0 && string(chunks[0]) == "slp\x00" {
+		chunks[0] = []byte("SLP\x00")
+	}
+	return chunks, err
+}
+
+func (c upperCaseLokadCodec) FirstChunk(script []byte) ([]byte, error) {
+	chunk, err := c.BCHCodec.FirstChunk(script)
+	if string(chunk) == "slp\x00" {
+		chunk = []byte("SLP\x00")
+	}
+	return chunk, err
+}
+
+func TestParseSLPWithOptionsCodec(t *testing.T) {
+	script := buildScript([]byte("slp\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), make([]byte, 8))
+
+	if _, err := ParseSLP(script); err == nil {
+		t.Fatal("expected default codec to reject lowercase lokad id")
+	}
+
+	r, err := ParseSLPWithOptions(script, ParseOptions{Codec: upperCaseLokadCodec{}})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r.TransactionType != "SEND" {
+		t.Errorf("expected SEND, got %s", r.TransactionType)
+	}
+}
+
+// opZeroPrefixCodec accepts the OP_0 OP_RETURN form which BCHCodec rejects
+type opZeroPrefixCodec struct {
+	BCHCodec
+}
+
+func (c opZeroPrefixCodec) DecodeChunks(script []byte) ([][]byte, error) {
+	if len(script) > 0 && script[0] == 0x00 {
+		script = script[1:]
+	}
+	return c.BCHCodec.DecodeChunks(script)
+}
+
+func (c opZeroPrefixCodec) FirstChunk(script []byte) ([]byte, error) {
+	if len(script) > 0 && script[0] == 0x00 {
+		script = script[1:]
+	}
+	return c.BCHCodec.FirstChunk(script)
+}
+
+func TestParseSLPWithOptionsCodecFraming(t *testing.T) {
+	script := append([]byte{0x00}, buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), make([]byte, 8))...)
+
+	if _, err := ParseSLP(script); !errors.Is(err, ErrNotOpReturn) {
+		t.Fatalf("expected default codec to reject OP_0 prefix, got %v", err)
+	}
+
+	if _, err := ParseSLPWithOptions(script, ParseOptions{Codec: opZeroPrefixCodec{}}); err != nil {
+		t.Errorf("expected custom codec framing to be used, got %v", err)
+	}
+}
+
+func TestBCHCodecPushdataOverrun(t *testing.T) {
+	tests := []struct {
+		name   string
+		script []byte
+		reason string
+	}{
+		{
+			"direct push",
+			append([]byte{0x6a, 0x01, 0x01, 0x32}, make([]byte, 10)...),
+			"pushdata length exceeds remaining bytes: claimed 50 bytes, 10 available",
+		},
+		{
+			"pushdata1",
+			append([]byte{0x6a, 0x4c, 0x32}, make([]byte, 10)...),
+			"pushdata length exceeds remaining bytes: claimed 50 bytes, 10 available",
+		},
+		{
+			"pushdata2 length",
+			codecScript(0x4d, 0x01),
+			"pushdata length exceeds remaining bytes: claimed 2 length bytes, 1 available",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := BCHCodec{}.DecodeChunks(test.script)
+		if !errors.Is(err, ErrPushdataOverrun) {
+			t.Errorf("%s: expected ErrPushdataOverrun, got %v", test.name, err)
+			continue
+		}
+		var perr *ParseError
+		if !errors.As(err, &perr) || perr.Reason.Error() != test.reason {
+			t.Errorf("%s: expected reason %q, got %v", test.name, test.reason, err)
+		}
+	}
+
+	// bytes after the last push which are not a push, including OP_0, are
+	// trailing data
+	for _, script := range [][]byte{codecScript(0x01, 0x01, 0xac), codecScript(0x01, 0x01, 0x00)} {
+		_, err := BCHCodec{}.DecodeChunks(script)
+		var trailing *TrailingDataError
+		if errors.Is(err, ErrPushdataOverrun) || !errors.As(err, &trailing) {
+			t.Errorf("%x: expected TrailingDataError, got %v", script, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDecimals is returned for a decimals value outside of 0-9
+var ErrInvalidDecimals = errors.New("decimals must be between 0 and 9")
+
+// GenesisDisplay holds the metadata of a genesis message formatted for display
+type GenesisDisplay struct {
+	Ticker        string
+	Name          string
+	DocumentURI   string
+	DocumentHash  string
+	Decimals      int
+	HasMintBaton  bool
+	InitialSupply string
+}
+
+// Display returns the metadata of the genesis message formatted for display.
+// Text fields are utf8 decoded, the documentHash is hex encoded, and the
+// initial supply is formatted using the token's decimals.
+func (g *SlpGenesis) Display() GenesisDisplay {
+	return GenesisDisplay{
+		Ticker:        g.TickerAsUtf8(),
+		Name:          g.NameAsUtf8(),
+		DocumentURI:   g.DocumentURIAsUtf8(),
+		DocumentHash:  g.DocumentHashAsHex(),
+		Decimals:      g.Decimals,
+		HasMintBaton:  g.MintBatonVout != 0,
+		InitialSupply: formatAmount(g.Qty, g.Decimals),
+	}
+}
+
+// FormattedAmounts returns each amount of the send formatted as a decimal
+// string with the token's decimals, in the format of GenesisDisplay
+func (s *SlpSend) FormattedAmounts(decimals int) []string {
+	formatted := make([]string, len(s.Amounts))
+	for i, amount := range s.Amounts {
+		formatted[i] = formatAmount(amount, decimals)
+	}
+
+	return formatted
+}
+
+// formatAmount formats a base unit amount as a decimal string with the
+// given number of decimal places
+func formatAmount(amount uint64, decimals int) string {
+	s := strconv.FormatUint(amount, 10)
+	if decimals <= 0 {
+		return s
+	}
+
+	if len(s) <= decimals {
+		s = strings.Repeat("0", decimals-len(s)+1) + s
+	}
+
+	return s[:len(s)-decimals] + "." + s[len(s)-decimals:]
+}
+
+// DecimalsMultiplier returns 10^decimals, the number of base units in one
+// whole token with the given decimals. ErrInvalidDecimals is returned if
+// decimals is outside of the 0-9 range allowed by SLP.
+func DecimalsMultiplier(decimals int) (uint64, error) {
+	if decimals < 0 || decimals > 9 {
+		return 0, ErrInvalidDecimals
+	}
+
+	m := uint64(1)
+	for i := 0; i < decimals; i++ {
+		m *= 10
+	}
+
+	return m, nil
+}
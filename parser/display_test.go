@@ -0,0 +1,99 @@
+package parser
+
+import "testing"
+
+func TestGenesisDisplay(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("GENESIS"),
+		[]byte("SPICE"),
+		[]byte("Spice"),
+		[]byte("https://spicetoken.org"),
+		[]byte{},
+		[]byte{0x08},
+		[]byte{0x02},
+		[]byte{0x01, 0x63, 0x45, 0x78, 0x5d, 0x8a, 0x00, 0x00},
+	)
+
+	r, err := ParseSLP(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := r.Data.(*SlpGenesis)
+	expected := GenesisDisplay{
+		Ticker:        "SPICE",
+		Name:          "Spice",
+		DocumentURI:   "https://spicetoken.org",
+		DocumentHash:  "",
+		Decimals:      8,
+		HasMintBaton:  true,
+		InitialSupply: "1000000000.00000000",
+	}
+	if d := g.Display(); d != expected {
+		t.Errorf("expected %+v, got %+v", expected, d)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   uint64
+		decimals int
+		expected string
+	}{
+		{0, 0, "0"},
+		{0, 2, "0.00"},
+		{5, 2, "0.05"},
+		{100, 2, "1.00"},
+		{123456, 3, "123.456"},
+		{1, 9, "0.000000001"},
+		{MaxSlpAmount, 0, "18446744073709551615"},
+		{MaxSlpAmount, 9, "18446744073.709551615"},
+	}
+
+	for _, test := range tests {
+		if s := formatAmount(test.amount, test.decimals); s != test.expected {
+			t.Errorf("formatAmount(%d, %d) = %s, expected %s", test.amount, test.decimals, s, test.expected)
+		}
+	}
+}
+
+func TestDecimalsMultiplier(t *testing.T) {
+	expected := uint64(1)
+	for decimals := 0; decimals <= 9; decimals++ {
+		m, err := DecimalsMultiplier(decimals)
+		if err != nil {
+			t.Fatalf("decimals %d: unexpected error %v", decimals, err)
+		}
+		if m != expected {
+			t.Errorf("decimals %d: expected %d, got %d", decimals, expected, m)
+		}
+		expected *= 10
+	}
+
+	for _, decimals := range []int{-1, 10} {
+		if _, err := DecimalsMultiplier(decimals); err != ErrInvalidDecimals {
+			t.Errorf("decimals %d: expected ErrInvalidDecimals, got %v", decimals, err)
+		}
+	}
+}
+
+func TestFormattedAmounts(t *testing.T) {
+	s := SlpSend{Amounts: []uint64{123000000, 0, 1, 2100000000000000}}
+	expected := []string{"1.23000000", "0.00000000", "0.00000001", "21000000.00000000"}
+
+	formatted := s.FormattedAmounts(8)
+	if len(formatted) != len(expected) {
+		t.Fatalf("expected %d amounts, got %d", len(expected), len(formatted))
+	}
+	for i := range expected {
+		if formatted[i] != expected[i] {
+			t.Errorf("amount %d: expected %s, got %s", i, expected[i], formatted[i])
+		}
+	}
+
+	if formatted := s.FormattedAmounts(0); formatted[0] != "123000000" || formatted[1] != "0" {
+		t.Errorf("unexpected amounts with 0 decimals: %v", formatted)
+	}
+}
@@ -0,0 +1,8 @@
+// Package parser parses and encodes SLP OP_RETURN messages.
+//
+// The package works on raw scriptPubKey bytes and only depends on the
+// standard library, so it can be embedded without pulling in a Bitcoin
+// library. Helpers which operate on whole transactions, such as
+// ParseTransaction, GenesisTokenID, and the output validators, live in the
+// wireutil subpackage, which depends on github.com/gcash/bchd/wire.
+package parser
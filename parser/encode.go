@@ -0,0 +1,431 @@
+package parser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Encode creates the OP_RETURN scriptPubKey for the parsed result using the
+// encoder for its transaction type.
+//
+// Encoders always push data using the smallest possible opcode and encode
+// empty fields as OP_PUSHDATA1 with a length of 0. A script which was parsed
+// from non-minimal pushdata will not byte-match the encoded result.
+//
+// The token type is encoded using TokenTypeBytes if it is set, otherwise as
+// a single byte.
+//
+// TrailingBytes are not written, since the encoded script is always a valid
+// SLP message. A script parsed with ParseOptions.AllowTrailingData therefore
+// encodes to the script without its trailing data.
+func (r *ParseResult) Encode() ([]byte, error) {
+	tokenTypeBuf := r.TokenTypeBytes
+	if len(tokenTypeBuf) == 0 {
+		tokenTypeBuf = encodeTokenType(r.TokenType)
+	}
+
+	if err := parseCheck(decodeTokenType(tokenTypeBuf) != r.TokenType, "TokenTypeBytes does not match TokenType"); err != nil {
+		return nil, err
+	}
+
+	switch r.TransactionType {
+	case "GENESIS":
+		g, ok := r.Data.(*SlpGenesis)
+		if !ok {
+			return nil, errors.New("GENESIS data is not *SlpGenesis")
+		}
+		return encodeGenesis(*g, r.TokenType, tokenTypeBuf)
+	case "MINT":
+		m, ok := r.Data.(*SlpMint)
+		if !ok {
+			return nil, errors.New("MINT data is not *SlpMint")
+		}
+		return encodeMint(*m, r.TokenType, tokenTypeBuf)
+	case "SEND":
+		s, ok := r.Data.(*SlpSend)
+		if !ok {
+			return nil, errors.New("SEND data is not *SlpSend")
+		}
+		return encodeSend(*s, r.TokenType, tokenTypeBuf)
+	}
+
+	return nil, errors.New("unknown transaction type")
+}
+
+// WriteTo encodes the result and writes the script to w, implementing
+// io.WriterTo. Nothing is written if the result cannot be encoded. As with
+// Encode, TrailingBytes are not written.
+func (r *ParseResult) WriteTo(w io.Writer) (int64, error) {
+	script, err := r.Encode()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(script)
+	return int64(n), err
+}
+
+// EncodeGenesis creates the OP_RETURN scriptPubKey for a genesis message
+func EncodeGenesis(g SlpGenesis, tokenType int) ([]byte, error) {
+	return encodeGenesis(g, tokenType, encodeTokenType(tokenType))
+}
+
+func encodeGenesis(g SlpGenesis, tokenType int, tokenTypeBuf []byte) ([]byte, error) {
+	if err := checkTokenType(tokenType); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateGenesis(g, tokenType); err != nil {
+		return nil, err
+	}
+
+	return encodeChunks(
+		lokadID,
+		tokenTypeBuf,
+		[]byte("GENESIS"),
+		g.Ticker,
+		g.Name,
+		g.DocumentURI,
+		g.DocumentHash,
+		[]byte{byte(g.Decimals)},
+		encodeMintBatonVout(g.MintBatonVout),
+		encodeAmount(g.Qty),
+	), nil
+}
+
+// Conventional maximum lengths for GENESIS text fields. The SLP spec does not
+// limit these fields, but token registries and wallets generally expect
+// fields within these lengths.
+const (
+	MaxTickerLength      = 16
+	MaxNameLength        = 64
+	MaxDocumentURILength = 128
+)
+
+// MaxScriptSize is the largest OP_RETURN scriptPubKey relayed by standard
+// BCH nodes
+const MaxScriptSize = 223
+
+// ErrFieldTooLong is returned when EncodeOptions.EnforceFieldLimits is set
+// and a GENESIS text field is longer than its maximum length
+var ErrFieldTooLong = errors.New("field longer than maximum length")
+
+// ErrScriptTooLarge is returned when EncodeOptions.EnforceFieldLimits is set
+// and the encoded script is larger than MaxScriptSize
+var ErrScriptTooLarge = errors.New("script larger than MaxScriptSize")
+
+// EncodeOptions controls optional checks made when encoding. The zero value
+// matches EncodeGenesis.
+type EncodeOptions struct {
+	// EnforceFieldLimits rejects GENESIS messages with a ticker, name, or
+	// documentURI longer than MaxTickerLength, MaxNameLength, or
+	// MaxDocumentURILength, or which encode to more than MaxScriptSize bytes
+	EnforceFieldLimits bool
+}
+
+// EncodeGenesisWithOptions is EncodeGenesis with additional checks set by opts
+func EncodeGenesisWithOptions(g SlpGenesis, tokenType int, opts EncodeOptions) ([]byte, error) {
+	if opts.EnforceFieldLimits {
+		if err := checkGenesisFieldLimits(g); err != nil {
+			return nil, err
+		}
+	}
+
+	return EncodeGenesis(g, tokenType)
+}
+
+func checkGenesisFieldLimits(g SlpGenesis) error {
+	if err := chunkCheckReason(len(g.Ticker) > MaxTickerLength, 3, "ticker", ErrFieldTooLong); err != nil {
+		return err
+	}
+
+	if err := chunkCheckReason(len(g.Name) > MaxNameLength, 4, "name", ErrFieldTooLong); err != nil {
+		return err
+	}
+
+	if err := chunkCheckReason(len(g.DocumentURI) > MaxDocumentURILength, 5, "document_uri", ErrFieldTooLong); err != nil {
+		return err
+	}
+
+	if GenesisScriptSize(g) > MaxScriptSize {
+		return ErrScriptTooLarge
+	}
+
+	return nil
+}
+
+// EncodeMint creates the OP_RETURN scriptPubKey for a mint message
+func EncodeMint(m SlpMint, tokenType int) ([]byte, error) {
+	return encodeMint(m, tokenType, encodeTokenType(tokenType))
+}
+
+func encodeMint(m SlpMint, tokenType int, tokenTypeBuf []byte) ([]byte, error) {
+	if err := checkTokenType(tokenType); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateMint(m, tokenType); err != nil {
+		return nil, err
+	}
+
+	return encodeChunks(
+		lokadID,
+		tokenTypeBuf,
+		[]byte("MINT"),
+		m.TokenID,
+		encodeMintBatonVout(m.MintBatonVout),
+		encodeAmount(m.Qty),
+	), nil
+}
+
+// EncodeSend creates the OP_RETURN scriptPubKey for a send message
+func EncodeSend(s SlpSend, tokenType int) ([]byte, error) {
+	return encodeSend(s, tokenType, encodeTokenType(tokenType))
+}
+
+func encodeSend(s SlpSend, tokenType int, tokenTypeBuf []byte) ([]byte, error) {
+	if err := checkTokenType(tokenType); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateSend(s); err != nil {
+		return nil, err
+	}
+
+	chunks := [][]byte{
+		lokadID,
+		tokenTypeBuf,
+		[]byte("SEND"),
+		s.TokenID,
+	}
+	for _, amount := range s.Amounts {
+		chunks = append(chunks, encodeAmount(amount))
+	}
+
+	return encodeChunks(chunks...), nil
+}
+
+// ErrNegativeAmount is returned by EncodeSendSigned when an amount is
+// negative
+var ErrNegativeAmount = errors.New("amount is negative")
+
+// EncodeSendSigned creates the OP_RETURN scriptPubKey for a send message
+// from signed amounts, as read from user input. Negative amounts are
+// rejected rather than wrapping to a huge uint64.
+func EncodeSendSigned(tokenID []byte, tokenType int, amounts []int64) ([]byte, error) {
+	s := SlpSend{
+		TokenID: tokenID,
+		Amounts: make([]uint64, len(amounts)),
+	}
+	for i, amount := range amounts {
+		if amount < 0 {
+			return nil, fmt.Errorf("%w: token_amount %d is %d", ErrNegativeAmount, i, amount)
+		}
+		s.Amounts[i] = uint64(amount)
+	}
+
+	return EncodeSend(s, tokenType)
+}
+
+// BuildSend creates a send message and its OP_RETURN scriptPubKey which
+// sends outputs[i] to transaction output i+1. Outputs usually holds the
+// recipient amounts followed by the change amount. Between 1 and 19 outputs
+// are allowed.
+func BuildSend(tokenID []byte, tokenType int, outputs []uint64) (*SlpSend, []byte, error) {
+	s := &SlpSend{
+		TokenID: make([]byte, len(tokenID)),
+		Amounts: make([]uint64, len(outputs)),
+	}
+	copy(s.TokenID, tokenID)
+	copy(s.Amounts, outputs)
+
+	script, err := EncodeSend(*s, tokenType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s, script, nil
+}
+
+// SplitSend splits outputs into as few send messages as possible, each with
+// at most 19 amounts, for paying more recipients than one transaction
+// allows. Each message is for a separate transaction, and ensuring each of
+// those transactions has inputs covering its amounts is up to the caller.
+func SplitSend(tokenID []byte, tokenType int, outputs []uint64) ([]*SlpSend, error) {
+	if len(outputs) == 0 {
+		return nil, ErrNoSendAmounts
+	}
+
+	sends := make([]*SlpSend, 0, (len(outputs)+DefaultMaxSendOutputs-1)/DefaultMaxSendOutputs)
+	for start := 0; start < len(outputs); start += DefaultMaxSendOutputs {
+		end := start + DefaultMaxSendOutputs
+		if end > len(outputs) {
+			end = len(outputs)
+		}
+
+		s, _, err := BuildSend(tokenID, tokenType, outputs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		sends = append(sends, s)
+	}
+
+	return sends, nil
+}
+
+// MinimumBchOutputs returns the number of dust outputs, besides the OP_RETURN,
+// which a transaction carrying msg must have to hold its tokens: the token
+// output plus the mint baton output, if any, for GENESIS and MINT, and one
+// output per amount for SEND. msg is a *SlpGenesis, *SlpMint, or *SlpSend
+// matching txType, and 0 is returned for any other combination.
+func MinimumBchOutputs(txType string, msg SlpOpReturn) int {
+	switch m := msg.(type) {
+	case *SlpGenesis:
+		if txType != "GENESIS" {
+			return 0
+		}
+		if m.MintBatonVout != 0 {
+			return 2
+		}
+		return 1
+	case *SlpMint:
+		if txType != "MINT" {
+			return 0
+		}
+		if m.MintBatonVout != 0 {
+			return 2
+		}
+		return 1
+	case *SlpSend:
+		if txType != "SEND" {
+			return 0
+		}
+		return len(m.Amounts)
+	}
+
+	return 0
+}
+
+// GenesisScriptSize returns the length of the script EncodeGenesis creates
+// for g, without encoding it
+func GenesisScriptSize(g SlpGenesis) int {
+	return 1 +
+		pushdataSize(len(lokadID)) +
+		pushdataSize(1) +
+		pushdataSize(len("GENESIS")) +
+		pushdataSize(len(g.Ticker)) +
+		pushdataSize(len(g.Name)) +
+		pushdataSize(len(g.DocumentURI)) +
+		pushdataSize(len(g.DocumentHash)) +
+		pushdataSize(1) +
+		pushdataSize(len(encodeMintBatonVout(g.MintBatonVout))) +
+		pushdataSize(8)
+}
+
+// MintScriptSize returns the length of the script EncodeMint creates for m,
+// without encoding it
+func MintScriptSize(m SlpMint) int {
+	return 1 +
+		pushdataSize(len(lokadID)) +
+		pushdataSize(1) +
+		pushdataSize(len("MINT")) +
+		pushdataSize(len(m.TokenID)) +
+		pushdataSize(len(encodeMintBatonVout(m.MintBatonVout))) +
+		pushdataSize(8)
+}
+
+// SendScriptSize returns the length of the script EncodeSend creates for s,
+// without encoding it
+func SendScriptSize(s SlpSend) int {
+	return 1 +
+		pushdataSize(len(lokadID)) +
+		pushdataSize(1) +
+		pushdataSize(len("SEND")) +
+		pushdataSize(len(s.TokenID)) +
+		len(s.Amounts)*pushdataSize(8)
+}
+
+func encodeTokenType(tokenType int) []byte {
+	return []byte{byte(tokenType)}
+}
+
+// decodeTokenType decodes a 1 or 2 byte token_type chunk, returning -1 for
+// any other length
+func decodeTokenType(tokenTypeBuf []byte) int {
+	switch len(tokenTypeBuf) {
+	case 1:
+		return int(tokenTypeBuf[0])
+	case 2:
+		return int(binary.BigEndian.Uint16(tokenTypeBuf))
+	}
+
+	return -1
+}
+
+func encodeMintBatonVout(mintBatonVout int) []byte {
+	if mintBatonVout == 0 {
+		return []byte{}
+	}
+
+	return []byte{byte(mintBatonVout)}
+}
+
+func encodeAmount(amount uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, amount)
+	return buf
+}
+
+func encodeChunks(chunks ...[]byte) []byte {
+	script := []byte{0x6a}
+	for _, chunk := range chunks {
+		script = appendPushdata(script, chunk)
+	}
+	return script
+}
+
+func appendPushdata(script []byte, data []byte) []byte {
+	opcode, prefix, _ := MinimalPushOpcode(len(data))
+	script = append(script, opcode)
+	script = append(script, prefix...)
+	return append(script, data...)
+}
+
+// pushdataSize returns the number of bytes used to push dataLen bytes
+func pushdataSize(dataLen int) int {
+	_, prefix, _ := MinimalPushOpcode(dataLen)
+	return 1 + len(prefix) + dataLen
+}
+
+// ErrPushTooLarge is returned by MinimalPushOpcode for a length which cannot
+// be pushed by a single opcode
+var ErrPushTooLarge = errors.New("pushdata length exceeds OP_PUSHDATA4 maximum")
+
+// MinimalPushOpcode returns the smallest opcode able to push dataLen bytes,
+// and the little endian length bytes which follow an OP_PUSHDATA opcode.
+// Empty data uses OP_PUSHDATA1 with a length of 0 since SLP does not allow
+// OP_0. ErrPushTooLarge is returned if dataLen is negative or larger than
+// OP_PUSHDATA4 can push.
+func MinimalPushOpcode(dataLen int) (opcode byte, prefix []byte, err error) {
+	switch {
+	case dataLen < 0 || uint64(dataLen) > math.MaxUint32:
+		return 0, nil, ErrPushTooLarge
+	case dataLen == 0:
+		return 0x4c, []byte{0x00}, nil
+	case dataLen < 0x4c:
+		return byte(dataLen), nil, nil
+	case dataLen <= 0xff:
+		return 0x4c, []byte{byte(dataLen)}, nil
+	case dataLen <= 0xffff:
+		prefix = make([]byte, 2)
+		binary.LittleEndian.PutUint16(prefix, uint16(dataLen))
+		return 0x4d, prefix, nil
+	}
+
+	prefix = make([]byte, 4)
+	binary.LittleEndian.PutUint32(prefix, uint32(dataLen))
+	return 0x4e, prefix, nil
+}
@@ -0,0 +1,541 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	amount := []byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8}
+	tokenID := bytes.Repeat([]byte{0xab}, 32)
+
+	scripts := map[string][]byte{
+		"genesis": buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("GENESIS"),
+			[]byte("TOK"),
+			[]byte("Token"),
+			[]byte("https://simpleledger.cash"),
+			bytes.Repeat([]byte{0xcd}, 32),
+			[]byte{0x08},
+			[]byte{0x02},
+			amount,
+		),
+		"genesis with empty fields": buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("GENESIS"),
+			[]byte{},
+			[]byte{},
+			[]byte{},
+			[]byte{},
+			[]byte{0x00},
+			[]byte{},
+			amount,
+		),
+		"genesis with long name": buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x81},
+			[]byte("GENESIS"),
+			[]byte("TOK"),
+			bytes.Repeat([]byte("n"), 100),
+			[]byte{},
+			[]byte{},
+			[]byte{0x00},
+			[]byte{0x02},
+			amount,
+		),
+		"nft1 child genesis": buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x41},
+			[]byte("GENESIS"),
+			[]byte("NFT"),
+			[]byte("My NFT"),
+			[]byte{},
+			[]byte{},
+			[]byte{0x00},
+			[]byte{},
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		),
+		"mint": buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("MINT"),
+			tokenID,
+			[]byte{0x02},
+			amount,
+		),
+		"mint without baton": buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x81},
+			[]byte("MINT"),
+			tokenID,
+			[]byte{},
+			amount,
+		),
+		"send": buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("SEND"),
+			tokenID,
+			amount,
+			amount,
+			[]byte{0, 0, 0, 0, 0, 0, 0, 0},
+		),
+	}
+
+	for name, script := range scripts {
+		r, err := ParseSLP(script)
+		if err != nil {
+			t.Errorf("%s: parse failed: %v", name, err)
+			continue
+		}
+
+		encoded, err := r.Encode()
+		if err != nil {
+			t.Errorf("%s: encode failed: %v", name, err)
+			continue
+		}
+
+		if !bytes.Equal(encoded, script) {
+			t.Errorf("%s: encoded script %x does not match original %x", name, encoded, script)
+		}
+	}
+}
+
+func TestEncodeDropsTrailingBytes(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		bytes.Repeat([]byte{0xab}, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+	withTrailing := append(append([]byte{}, script...), 0x51, 0xac)
+
+	r, err := ParseSLPWithOptions(withTrailing, ParseOptions{AllowTrailingData: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := r.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, script) {
+		t.Errorf("expected encoded script %x without trailing bytes, got %x", script, encoded)
+	}
+
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil || !bytes.Equal(buf.Bytes(), script) {
+		t.Errorf("expected written script %x without trailing bytes, got %x, %v", script, buf.Bytes(), err)
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	if _, err := EncodeGenesis(SlpGenesis{Decimals: 10}, 0x01); err == nil {
+		t.Error("expected error for invalid genesis")
+	}
+
+	if _, err := EncodeMint(SlpMint{TokenID: make([]byte, 32)}, 0x41); err == nil {
+		t.Error("expected error for nft1 child mint")
+	}
+
+	if _, err := EncodeSend(SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{1}}, 0x02); err == nil {
+		t.Error("expected error for unknown token type")
+	}
+
+	r := &ParseResult{TokenType: 0x01, TransactionType: "BURN"}
+	if _, err := r.Encode(); err == nil {
+		t.Error("expected error for unknown transaction type")
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		bytes.Repeat([]byte{0xab}, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 0x01},
+	)
+
+	r, err := ParseSLP(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := r.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var _ io.WriterTo = r
+
+	var buf bytes.Buffer
+	buf.WriteString("prefix")
+	n, err := r.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(encoded)) {
+		t.Errorf("expected %d bytes written, got %d", len(encoded), n)
+	}
+	if !bytes.Equal(buf.Bytes()[len("prefix"):], encoded) {
+		t.Errorf("written script %x does not match encoded %x", buf.Bytes()[len("prefix"):], encoded)
+	}
+
+	invalid := &ParseResult{TokenType: 0x01, TransactionType: "BURN"}
+	buf.Reset()
+	if n, err := invalid.WriteTo(&buf); err == nil || n != 0 || buf.Len() != 0 {
+		t.Errorf("expected error and nothing written for invalid result, got n=%d err=%v", n, err)
+	}
+}
+
+func TestScriptSize(t *testing.T) {
+	geneses := []SlpGenesis{
+		{Qty: 1},
+		{
+			Ticker:        []byte("TOK"),
+			Name:          bytes.Repeat([]byte("n"), 300),
+			DocumentURI:   bytes.Repeat([]byte("u"), 80),
+			DocumentHash:  make([]byte, 32),
+			Decimals:      9,
+			MintBatonVout: 2,
+			Qty:           MaxSlpAmount,
+		},
+	}
+	for _, g := range geneses {
+		script, err := EncodeGenesis(g, 0x01)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := GenesisScriptSize(g); size != len(script) {
+			t.Errorf("GenesisScriptSize = %d, expected %d", size, len(script))
+		}
+	}
+
+	mints := []SlpMint{
+		{TokenID: make([]byte, 32), Qty: 1},
+		{TokenID: make([]byte, 32), MintBatonVout: 2, Qty: 1},
+	}
+	for _, m := range mints {
+		script, err := EncodeMint(m, 0x01)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := MintScriptSize(m); size != len(script) {
+			t.Errorf("MintScriptSize = %d, expected %d", size, len(script))
+		}
+	}
+
+	for _, n := range []int{1, 2, 19} {
+		s := SlpSend{TokenID: make([]byte, 32), Amounts: make([]uint64, n)}
+		script, err := EncodeSend(s, 0x01)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := SendScriptSize(s); size != len(script) {
+			t.Errorf("SendScriptSize with %d amounts = %d, expected %d", n, size, len(script))
+		}
+	}
+}
+
+func TestBuildSend(t *testing.T) {
+	tokenID := bytes.Repeat([]byte{0xab}, 32)
+	inputTotal := uint64(1000)
+	recipients := []uint64{100, 250, 50}
+
+	change := inputTotal
+	for _, amount := range recipients {
+		change -= amount
+	}
+	outputs := append(recipients, change)
+
+	s, script, err := BuildSend(tokenID, 0x01, outputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total, err := s.OutputAmountSum()
+	if err != nil || total != inputTotal {
+		t.Errorf("expected outputs to total %d, got %d, %v", inputTotal, total, err)
+	}
+
+	r, err := ParseSLP(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed := r.Data.(*SlpSend)
+	if !parsed.Equal(*s) {
+		t.Errorf("parsed send %v does not match built send %v", parsed, s)
+	}
+
+	// the built send does not alias the arguments
+	outputs[0] = 0
+	tokenID[0] = 0
+	if s.Amounts[0] != 100 || s.TokenID[0] != 0xab {
+		t.Error("built send shares memory with arguments")
+	}
+
+	if _, _, err := BuildSend(tokenID, 0x01, nil); err == nil {
+		t.Error("expected error for no outputs")
+	}
+	if _, _, err := BuildSend(tokenID, 0x01, make([]uint64, 20)); err == nil {
+		t.Error("expected error for 20 outputs")
+	}
+	if _, _, err := BuildSend(tokenID[:31], 0x01, outputs); err == nil {
+		t.Error("expected error for invalid tokenID")
+	}
+}
+
+func TestEncodeSendSigned(t *testing.T) {
+	tokenID := bytes.Repeat([]byte{0xab}, 32)
+
+	script, err := EncodeSendSigned(tokenID, 0x01, []int64{1000, 0, math.MaxInt64})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := EncodeSend(SlpSend{TokenID: tokenID, Amounts: []uint64{1000, 0, math.MaxInt64}}, 0x01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(script, expected) {
+		t.Errorf("expected script %x, got %x", expected, script)
+	}
+
+	if _, err := EncodeSendSigned(tokenID, 0x01, []int64{1000, -1}); !errors.Is(err, ErrNegativeAmount) {
+		t.Errorf("expected ErrNegativeAmount, got %v", err)
+	}
+	if _, err := EncodeSendSigned(tokenID, 0x01, nil); err == nil {
+		t.Error("expected error for no amounts")
+	}
+}
+
+func TestEncodeTwoByteTokenType(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x00, 0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	r, err := ParseSLP(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TokenType != 0x01 || !bytes.Equal(r.TokenTypeBytes, []byte{0x00, 0x01}) {
+		t.Errorf("expected 2 byte token type 0x0001, got %d %x", r.TokenType, r.TokenTypeBytes)
+	}
+
+	encoded, err := r.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, script) {
+		t.Errorf("encoded script %x does not match original %x", encoded, script)
+	}
+
+	// without TokenTypeBytes the token type is encoded as 1 byte
+	r.TokenTypeBytes = nil
+	encoded, err = r.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encoded) != len(script)-1 {
+		t.Errorf("expected 1 byte token type encoding, got %x", encoded)
+	}
+
+	r.TokenTypeBytes = []byte{0x00, 0x81}
+	if _, err := r.Encode(); err == nil {
+		t.Error("expected error for TokenTypeBytes not matching TokenType")
+	}
+}
+
+func TestMinimalPushOpcode(t *testing.T) {
+	tests := []struct {
+		dataLen int
+		opcode  byte
+		prefix  []byte
+	}{
+		{0, 0x4c, []byte{0x00}},
+		{1, 0x01, nil},
+		{75, 0x4b, nil},
+		{76, 0x4c, []byte{0x4c}},
+		{255, 0x4c, []byte{0xff}},
+		{256, 0x4d, []byte{0x00, 0x01}},
+		{65535, 0x4d, []byte{0xff, 0xff}},
+		{65536, 0x4e, []byte{0x00, 0x00, 0x01, 0x00}},
+		{math.MaxUint32, 0x4e, []byte{0xff, 0xff, 0xff, 0xff}},
+	}
+
+	for _, test := range tests {
+		opcode, prefix, err := MinimalPushOpcode(test.dataLen)
+		if err != nil {
+			t.Errorf("%d: unexpected error %v", test.dataLen, err)
+			continue
+		}
+		if opcode != test.opcode || !bytes.Equal(prefix, test.prefix) {
+			t.Errorf("%d: expected opcode 0x%02x prefix %x, got 0x%02x prefix %x",
+				test.dataLen, test.opcode, test.prefix, opcode, prefix)
+		}
+	}
+
+	for _, dataLen := range []int{-1, math.MaxUint32 + 1} {
+		if _, _, err := MinimalPushOpcode(dataLen); err != ErrPushTooLarge {
+			t.Errorf("%d: expected ErrPushTooLarge, got %v", dataLen, err)
+		}
+	}
+}
+
+func TestEncodeGenesisWithOptionsFieldLimits(t *testing.T) {
+	g := SlpGenesis{
+		Ticker: bytes.Repeat([]byte("T"), MaxTickerLength+1),
+		Name:   []byte("Token"),
+		Qty:    100,
+	}
+
+	if _, err := EncodeGenesisWithOptions(g, 0x01, EncodeOptions{}); err != nil {
+		t.Errorf("limits off: unexpected error %v", err)
+	}
+
+	_, err := EncodeGenesisWithOptions(g, 0x01, EncodeOptions{EnforceFieldLimits: true})
+	var parseErr *ParseError
+	if !errors.Is(err, ErrFieldTooLong) || !errors.As(err, &parseErr) || parseErr.Field != "ticker" {
+		t.Errorf("limits on: expected ErrFieldTooLong for ticker, got %v", err)
+	}
+
+	g.Ticker = g.Ticker[:MaxTickerLength]
+	if _, err := EncodeGenesisWithOptions(g, 0x01, EncodeOptions{EnforceFieldLimits: true}); err != nil {
+		t.Errorf("limits on at max ticker length: unexpected error %v", err)
+	}
+
+	g.Name = bytes.Repeat([]byte("N"), MaxNameLength)
+	g.DocumentURI = bytes.Repeat([]byte("u"), MaxDocumentURILength)
+	if _, err := EncodeGenesisWithOptions(g, 0x01, EncodeOptions{EnforceFieldLimits: true}); err != ErrScriptTooLarge {
+		t.Errorf("limits on with all fields at max length: expected ErrScriptTooLarge, got %v", err)
+	}
+}
+
+func TestMinimumBchOutputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		txType   string
+		msg      SlpOpReturn
+		expected int
+	}{
+		{"genesis with baton", "GENESIS", &SlpGenesis{MintBatonVout: 2, Qty: 1}, 2},
+		{"genesis without baton", "GENESIS", &SlpGenesis{Qty: 1}, 1},
+		{"mint with baton", "MINT", &SlpMint{MintBatonVout: 2}, 2},
+		{"mint without baton", "MINT", &SlpMint{}, 1},
+		{"3 output send", "SEND", &SlpSend{Amounts: []uint64{1, 2, 3}}, 3},
+		{"mismatched type", "SEND", &SlpMint{}, 0},
+		{"nil message", "SEND", nil, 0},
+	}
+
+	for _, test := range tests {
+		if v := MinimumBchOutputs(test.txType, test.msg); v != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.name, test.expected, v)
+		}
+	}
+}
+
+func TestSplitSend(t *testing.T) {
+	tokenID := bytes.Repeat([]byte{0xab}, 32)
+	outputs := make([]uint64, 45)
+	for i := range outputs {
+		outputs[i] = uint64(i + 1)
+	}
+
+	sends, err := SplitSend(tokenID, 0x01, outputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sizes := []int{19, 19, 7}
+	if len(sends) != len(sizes) {
+		t.Fatalf("expected %d sends, got %d", len(sizes), len(sends))
+	}
+
+	next := uint64(1)
+	for i, s := range sends {
+		if len(s.Amounts) != sizes[i] {
+			t.Errorf("send %d: expected %d amounts, got %d", i, sizes[i], len(s.Amounts))
+		}
+		if !s.IsForToken(tokenID) {
+			t.Errorf("send %d: wrong tokenID %x", i, s.TokenID)
+		}
+		for _, amount := range s.Amounts {
+			if amount != next {
+				t.Errorf("send %d: expected amount %d, got %d", i, next, amount)
+			}
+			next++
+		}
+	}
+
+	outputs[0] = 100
+	if sends[0].Amounts[0] != 1 {
+		t.Error("expected amounts to be copied")
+	}
+
+	if sends, err := SplitSend(tokenID, 0x01, outputs[:19]); err != nil || len(sends) != 1 {
+		t.Errorf("19 outputs: expected 1 send, got %d %v", len(sends), err)
+	}
+	if _, err := SplitSend(tokenID, 0x01, nil); !errors.Is(err, ErrNoSendAmounts) {
+		t.Errorf("expected ErrNoSendAmounts, got %v", err)
+	}
+	if _, err := SplitSend(tokenID[:31], 0x01, outputs); err == nil {
+		t.Error("expected error for short tokenID")
+	}
+}
+
+func TestTwoByteTokenTypeNormalization(t *testing.T) {
+	genesis := func(tokenType []byte) []byte {
+		return buildScript([]byte("SLP\x00"), tokenType, []byte("GENESIS"), []byte{}, []byte{}, []byte{}, []byte{},
+			[]byte{0x00}, []byte{}, []byte{0, 0, 0, 0, 0, 0, 0, 1})
+	}
+
+	tests := []struct {
+		oneByte []byte
+		twoByte []byte
+	}{
+		{[]byte{0x01}, []byte{0x00, 0x01}},
+		{[]byte{0x41}, []byte{0x00, 0x41}},
+		{[]byte{0x81}, []byte{0x00, 0x81}},
+	}
+
+	for _, test := range tests {
+		a, err := ParseSLP(genesis(test.oneByte))
+		if err != nil {
+			t.Fatalf("%x: %v", test.oneByte, err)
+		}
+		b, err := ParseSLP(genesis(test.twoByte))
+		if err != nil {
+			t.Fatalf("%x: %v", test.twoByte, err)
+		}
+		if a.TokenType != b.TokenType || !a.SemanticEqual(b) {
+			t.Errorf("expected %x and %x to parse to the same token type, got 0x%x and 0x%x",
+				test.oneByte, test.twoByte, a.TokenType, b.TokenType)
+		}
+	}
+
+	// the NFT1 Child rules apply to the 2 byte form
+	nft := buildScript([]byte("SLP\x00"), []byte{0x00, 0x41}, []byte("GENESIS"), []byte{}, []byte{}, []byte{}, []byte{},
+		[]byte{0x00}, []byte{}, []byte{0, 0, 0, 0, 0, 0, 0, 2})
+	if _, err := ParseSLP(nft); err == nil {
+		t.Error("expected NFT1 Child quantity rule for token type 0x0041")
+	}
+
+	for _, tokenType := range [][]byte{{0x01, 0x00}, {0x41, 0x00}, {0x01, 0x01}} {
+		if _, err := ParseSLP(genesis(tokenType)); err == nil {
+			t.Errorf("expected token type %x to be rejected", tokenType)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ErrNoTokenID is returned by ExtractTokenID for a GENESIS message, since the
+// tokenID of a new token is the hash of the GENESIS transaction
+var ErrNoTokenID = errors.New("GENESIS message has no tokenID")
+
+// ExtractTokenID returns the tokenID of a MINT or SEND message without
+// parsing the rest of the message. The tokenID is returned in the same byte
+// order as it appears in the script, matching SlpMint.TokenID and
+// SlpSend.TokenID.
+//
+// Only the chunks up to and including the tokenID are checked, so a tokenID
+// may be returned for a message which ParseSLP would reject.
+func ExtractTokenID(scriptPubKey []byte) ([]byte, error) {
+	chunks, err := extractLeadingChunks(scriptPubKey, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chunks) < 3 {
+		return nil, endedEarly(len(chunks), []string{"token_type", "transaction_type"}[len(chunks)-1])
+	}
+
+	switch string(chunks[2]) {
+	case "GENESIS":
+		return nil, ErrNoTokenID
+	case "MINT", "SEND":
+	default:
+		return nil, errors.New("unknown transaction type")
+	}
+
+	if len(chunks) < 4 {
+		return nil, endedEarly(3, "token_id")
+	}
+
+	if err := checkTokenID(3, chunks[3]); err != nil {
+		return nil, err
+	}
+
+	tokenID := make([]byte, len(chunks[3]))
+	copy(tokenID, chunks[3])
+	return tokenID, nil
+}
+
+// SameToken parses two SLP scripts and returns true if they are MINT or SEND
+// messages for the same tokenID. ErrNoTokenID is returned if either is a
+// GENESIS, use SameTokenWithGenesis to compare those.
+func SameToken(a, b []byte) (bool, error) {
+	return SameTokenWithGenesis(a, nil, b, nil)
+}
+
+// SameTokenWithGenesis is like SameToken, but takes the txid of each script's
+// transaction so that a GENESIS is compared using its txid as the tokenID.
+// The txids must be in the byte order used by SlpMint.TokenID and
+// SlpSend.TokenID, and are ignored for MINT and SEND messages.
+func SameTokenWithGenesis(aScript, aTxid, bScript, bTxid []byte) (bool, error) {
+	a, err := scriptTokenID(aScript, aTxid)
+	if err != nil {
+		return false, err
+	}
+
+	b, err := scriptTokenID(bScript, bTxid)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(a, b), nil
+}
+
+// scriptTokenID parses an SLP script and returns its tokenID, which is txid
+// for a GENESIS
+func scriptTokenID(scriptPubKey []byte, txid []byte) ([]byte, error) {
+	r, err := ParseSLP(scriptPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch data := r.Data.(type) {
+	case *SlpMint:
+		return data.TokenID, nil
+	case *SlpSend:
+		return data.TokenID, nil
+	}
+
+	if txid == nil {
+		return nil, ErrNoTokenID
+	}
+
+	if !checkValidTokenID(txid) {
+		return nil, ErrInvalidTokenID
+	}
+
+	return txid, nil
+}
+
+// PeekTransactionType returns the transaction type of an SLP message,
+// checking only the lokad id, token type, and transaction type chunks. It is
+// intended for routing scripts by type cheaply, so a type may be returned for
+// a message whose payload ParseSLP would reject.
+func PeekTransactionType(scriptPubKey []byte) (TransactionType, error) {
+	chunks, err := extractLeadingChunks(scriptPubKey, 3)
+	if err != nil {
+		return "", err
+	}
+
+	if len(chunks) < 3 {
+		return "", endedEarly(len(chunks), []string{"token_type", "transaction_type"}[len(chunks)-1])
+	}
+
+	switch t := TransactionType(chunks[2]); t {
+	case TransactionTypeGenesis, TransactionTypeMint, TransactionTypeSend:
+		return t, nil
+	}
+
+	return "", errors.New("unknown transaction type")
+}
+
+// FindLokadOffset returns the index of the first OP_RETURN in script which is
+// followed by a push of the SLP lokad id, or -1 if there is none.
+//
+// This is a recovery aid for forensic tools inspecting scripts where a buggy
+// wallet placed junk before the SLP message. A message found at an offset
+// other than 0 is not valid SLP, and ParseSLP never searches for one.
+func FindLokadOffset(script []byte) int {
+	for i := 0; i < len(script); i++ {
+		if script[i] != 0x6a {
+			continue
+		}
+
+		data, _, ok := readPushdata(script, i+1)
+		if ok && bytes.Equal(data, lokadID) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// extractLeadingChunks reads up to n pushdata chunks from the start of an SLP
+// scriptPubKey, checking the lokad id and token type if they are present.
+// The returned chunks reference scriptPubKey.
+func extractLeadingChunks(scriptPubKey []byte, n int) ([][]byte, error) {
+	if err := checkScriptPrefix(scriptPubKey); err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]byte, 0, n)
+	it := 1
+	for len(chunks) < n {
+		data, next, ok := readPushdata(scriptPubKey, it)
+		if !ok {
+			break
+		}
+		chunks = append(chunks, data)
+		it = next
+	}
+
+	if err := parseCheck(len(chunks) == 0, "chunks empty"); err != nil {
+		return nil, err
+	}
+
+	if err := chunkCheckReason(!bytes.Equal(chunks[0], lokadID), 0, "lokad_id", ErrNotSLP); err != nil {
+		return nil, err
+	}
+
+	if len(chunks) > 1 {
+		tokenTypeBuf := chunks[1]
+
+		if err := chunkCheck(len(tokenTypeBuf) != 1 && len(tokenTypeBuf) != 2, 1, "token_type",
+			"token_type string length must be 1 or 2"); err != nil {
+			return nil, err
+		}
+
+		if err := checkTokenType(decodeTokenType(tokenTypeBuf)); err != nil {
+			return nil, err
+		}
+	}
+
+	return chunks, nil
+}
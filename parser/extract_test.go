@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestExtractTokenID(t *testing.T) {
+	tokenID := bytes.Repeat([]byte{0xab}, 32)
+	amount := []byte{0, 0, 0, 0, 0, 0, 0, 1}
+
+	send := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), tokenID, amount)
+	if id, err := ExtractTokenID(send); err != nil || !bytes.Equal(id, tokenID) {
+		t.Errorf("expected send tokenID %x, got %x, %v", tokenID, id, err)
+	}
+
+	mint := buildScript([]byte("SLP\x00"), []byte{0x81}, []byte("MINT"), tokenID, []byte{}, amount)
+	if id, err := ExtractTokenID(mint); err != nil || !bytes.Equal(id, tokenID) {
+		t.Errorf("expected mint tokenID %x, got %x, %v", tokenID, id, err)
+	}
+
+	genesis := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte{}, []byte{},
+		[]byte{}, []byte{}, []byte{0x00}, []byte{}, amount)
+	if _, err := ExtractTokenID(genesis); err != ErrNoTokenID {
+		t.Errorf("expected ErrNoTokenID, got %v", err)
+	}
+
+	shortID := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), tokenID[:31], amount)
+	var parseErr *ParseError
+	if _, err := ExtractTokenID(shortID); !errors.As(err, &parseErr) || parseErr.Field != "token_id" {
+		t.Errorf("expected token_id error, got %v", err)
+	}
+
+	notSlp := buildScript([]byte("ABC\x00"), []byte{0x01}, []byte("SEND"), tokenID, amount)
+	if _, err := ExtractTokenID(notSlp); err == nil {
+		t.Error("expected error for non-slp script")
+	}
+
+	truncated := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"))
+	if _, err := ExtractTokenID(truncated); err == nil {
+		t.Error("expected error for truncated script")
+	}
+}
+
+func TestSameToken(t *testing.T) {
+	tokenID := bytes.Repeat([]byte{0xab}, 32)
+	otherID := bytes.Repeat([]byte{0xcd}, 32)
+	amount := []byte{0, 0, 0, 0, 0, 0, 0, 1}
+
+	genesis := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte{}, []byte{},
+		[]byte{}, []byte{}, []byte{0x00}, []byte{0x02}, amount)
+	send := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), tokenID, amount)
+	mint := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("MINT"), tokenID, []byte{0x02}, amount)
+	otherSend := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), otherID, amount)
+
+	if same, err := SameToken(send, mint); err != nil || !same {
+		t.Errorf("expected send and mint to be the same token, got %v, %v", same, err)
+	}
+	if same, err := SameToken(send, otherSend); err != nil || same {
+		t.Errorf("expected sends for different tokens to differ, got %v, %v", same, err)
+	}
+	if _, err := SameToken(genesis, send); err != ErrNoTokenID {
+		t.Errorf("expected ErrNoTokenID, got %v", err)
+	}
+
+	if same, err := SameTokenWithGenesis(genesis, tokenID, send, nil); err != nil || !same {
+		t.Errorf("expected genesis and send to be the same token, got %v, %v", same, err)
+	}
+	if same, err := SameTokenWithGenesis(genesis, otherID, send, nil); err != nil || same {
+		t.Errorf("expected genesis and send for another token to differ, got %v, %v", same, err)
+	}
+	if _, err := SameTokenWithGenesis(genesis, tokenID[:31], send, nil); err != ErrInvalidTokenID {
+		t.Errorf("expected ErrInvalidTokenID, got %v", err)
+	}
+	if _, err := SameToken(send, genesis[:len(genesis)-1]); err == nil {
+		t.Error("expected error for invalid script")
+	}
+}
+
+func TestFindLokadOffset(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	if offset := FindLokadOffset(script); offset != 0 {
+		t.Errorf("expected offset 0, got %d", offset)
+	}
+
+	junk := append([]byte{0x6a, 0x01, 0x6a}, script...)
+	if _, err := ParseSLP(junk); err == nil {
+		t.Error("expected ParseSLP to reject script with leading junk")
+	}
+
+	offset := FindLokadOffset(junk)
+	if offset != 3 {
+		t.Fatalf("expected offset 3, got %d", offset)
+	}
+	if _, err := ParseSLP(junk[offset:]); err != nil {
+		t.Errorf("unexpected error parsing from offset: %v", err)
+	}
+
+	if offset := FindLokadOffset([]byte{0x6a, 0x04, 'A', 'B', 'C', 0x00, 0x6a, 0x04, 'S', 'L', 'P'}); offset != -1 {
+		t.Errorf("expected -1, got %d", offset)
+	}
+}
+
+func TestPeekTransactionType(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   []byte
+		expected TransactionType
+		err      bool
+	}{
+		{
+			"send",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), make([]byte, 8)),
+			TransactionTypeSend,
+			false,
+		},
+		{
+			"send with malformed payload",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 31), []byte{0x01}),
+			TransactionTypeSend,
+			false,
+		},
+		{
+			"genesis missing fields",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte("TOK")),
+			TransactionTypeGenesis,
+			false,
+		},
+		{
+			"mint",
+			buildScript([]byte("SLP\x00"), []byte{0x81}, []byte("MINT"), make([]byte, 32)),
+			TransactionTypeMint,
+			false,
+		},
+		{
+			"unknown type",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("BURN"), make([]byte, 32)),
+			"",
+			true,
+		},
+		{
+			"invalid token type",
+			buildScript([]byte("SLP\x00"), []byte{0x02}, []byte("SEND"), make([]byte, 32)),
+			"",
+			true,
+		},
+		{
+			"not slp",
+			buildScript([]byte("ABC\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32)),
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		txType, err := PeekTransactionType(test.script)
+		if (err != nil) != test.err {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if txType != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, txType)
+		}
+	}
+
+	if _, err := ParseSLP(tests[1].script); err == nil {
+		t.Error("expected ParseSLP to reject malformed payload")
+	}
+}
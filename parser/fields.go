@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// FieldKind describes whether a byte field of a message holds text or
+// binary data, which determines how it should be displayed or serialized
+type FieldKind int
+
+const (
+	// FieldKindText fields are utf8 decoded, as with the AsUtf8 accessors
+	FieldKindText FieldKind = iota
+	// FieldKindBinary fields are hexidecimal encoded, as with the AsHex accessors
+	FieldKindBinary
+)
+
+// String returns the name of the field kind
+func (k FieldKind) String() string {
+	switch k {
+	case FieldKindText:
+		return "text"
+	case FieldKindBinary:
+		return "binary"
+	}
+
+	return "unknown"
+}
+
+// FieldKinds returns the kind of each byte field of a genesis message, keyed
+// by the field names used in ParseError
+func (g *SlpGenesis) FieldKinds() map[string]FieldKind {
+	return map[string]FieldKind{
+		"ticker":        FieldKindText,
+		"name":          FieldKindText,
+		"document_uri":  FieldKindText,
+		"document_hash": FieldKindBinary,
+	}
+}
+
+// FieldKinds returns the kind of each byte field of a mint message, keyed by
+// the field names used in ParseError
+func (m *SlpMint) FieldKinds() map[string]FieldKind {
+	return map[string]FieldKind{
+		"token_id": FieldKindBinary,
+	}
+}
+
+// FieldKinds returns the kind of each byte field of a send message, keyed by
+// the field names used in ParseError
+func (s *SlpSend) FieldKinds() map[string]FieldKind {
+	return map[string]FieldKind{
+		"token_id": FieldKindBinary,
+	}
+}
+
+// formatField formats a byte field for ToMap, hex encoding text fields too
+// if raw is set
+func formatField(b []byte, kind FieldKind, raw bool) string {
+	if raw || kind == FieldKindBinary {
+		return hex.EncodeToString(b)
+	}
+
+	return string(b)
+}
+
+// formatBatonVout formats a mint_baton_vout for ToMap, which is empty if
+// there is no baton
+func formatBatonVout(mintBatonVout int) string {
+	if mintBatonVout == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(mintBatonVout)
+}
+
+// ToMap returns the fields of the genesis message as strings, keyed by the
+// field names used in ParseError. Text fields are utf8 decoded unless raw is
+// set, in which case they are hex encoded like the binary fields.
+func (g *SlpGenesis) ToMap(raw bool) map[string]string {
+	kinds := g.FieldKinds()
+	return map[string]string{
+		"ticker":          formatField(g.Ticker, kinds["ticker"], raw),
+		"name":            formatField(g.Name, kinds["name"], raw),
+		"document_uri":    formatField(g.DocumentURI, kinds["document_uri"], raw),
+		"document_hash":   formatField(g.DocumentHash, kinds["document_hash"], raw),
+		"decimals":        strconv.Itoa(g.Decimals),
+		"mint_baton_vout": formatBatonVout(g.MintBatonVout),
+		"initial_qty":     strconv.FormatUint(g.Qty, 10),
+	}
+}
+
+// ToMap returns the fields of the mint message as strings, keyed by the
+// field names used in ParseError. raw has no effect, since a mint message
+// has no text fields.
+func (m *SlpMint) ToMap(raw bool) map[string]string {
+	return map[string]string{
+		"token_id":        hex.EncodeToString(m.TokenID),
+		"mint_baton_vout": formatBatonVout(m.MintBatonVout),
+		"additional_qty":  strconv.FormatUint(m.Qty, 10),
+	}
+}
+
+// ToMap returns the fields of the send message as strings. The amounts are
+// joined with commas under token_amounts. raw has no effect, since a send
+// message has no text fields.
+func (s *SlpSend) ToMap(raw bool) map[string]string {
+	amounts := make([]string, len(s.Amounts))
+	for i, amount := range s.Amounts {
+		amounts[i] = strconv.FormatUint(amount, 10)
+	}
+
+	return map[string]string{
+		"token_id":      hex.EncodeToString(s.TokenID),
+		"token_amounts": strings.Join(amounts, ","),
+	}
+}
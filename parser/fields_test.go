@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// accessorKinds derives field kinds from the AsUtf8 and AsHex accessor
+// method names of v, keyed by the accessor name prefix
+func accessorKinds(v interface{}) map[string]FieldKind {
+	kinds := make(map[string]FieldKind)
+	typ := reflect.TypeOf(v)
+	for i := 0; i < typ.NumMethod(); i++ {
+		name := typ.Method(i).Name
+		if strings.HasSuffix(name, "AsUtf8") {
+			kinds[strings.TrimSuffix(name, "AsUtf8")] = FieldKindText
+		} else if strings.HasSuffix(name, "AsHex") {
+			kinds[strings.TrimSuffix(name, "AsHex")] = FieldKindBinary
+		}
+	}
+	return kinds
+}
+
+func TestFieldKindsMatchAccessors(t *testing.T) {
+	accessorNames := map[string]string{
+		"ticker":        "Ticker",
+		"name":          "Name",
+		"document_uri":  "DocumentURI",
+		"document_hash": "DocumentHash",
+		"token_id":      "TokenID",
+	}
+
+	tests := []struct {
+		name      string
+		kinds     map[string]FieldKind
+		accessors map[string]FieldKind
+	}{
+		{"genesis", (&SlpGenesis{}).FieldKinds(), accessorKinds(&SlpGenesis{})},
+		{"mint", (&SlpMint{}).FieldKinds(), accessorKinds(&SlpMint{})},
+		{"send", (&SlpSend{}).FieldKinds(), accessorKinds(&SlpSend{})},
+	}
+
+	for _, test := range tests {
+		if len(test.kinds) != len(test.accessors) {
+			t.Errorf("%s: %d field kinds for %d accessors", test.name, len(test.kinds), len(test.accessors))
+		}
+
+		for field, kind := range test.kinds {
+			accessorKind, ok := test.accessors[accessorNames[field]]
+			if !ok {
+				t.Errorf("%s: no accessor for field %s", test.name, field)
+				continue
+			}
+			if kind != accessorKind {
+				t.Errorf("%s: field %s is %s but accessor is %s", test.name, field, kind, accessorKind)
+			}
+		}
+	}
+}
+
+func TestToMap(t *testing.T) {
+	g := &SlpGenesis{
+		Ticker:        []byte("TOK"),
+		Name:          []byte("Token"),
+		DocumentURI:   []byte{},
+		DocumentHash:  []byte{0xab},
+		Decimals:      2,
+		MintBatonVout: 0,
+		Qty:           1000,
+	}
+
+	expected := map[string]string{
+		"ticker":          "TOK",
+		"name":            "Token",
+		"document_uri":    "",
+		"document_hash":   "ab",
+		"decimals":        "2",
+		"mint_baton_vout": "",
+		"initial_qty":     "1000",
+	}
+	if m := g.ToMap(false); !reflect.DeepEqual(m, expected) {
+		t.Errorf("genesis: expected %v, got %v", expected, m)
+	}
+
+	expected["ticker"] = "544f4b"
+	expected["name"] = "546f6b656e"
+	if m := g.ToMap(true); !reflect.DeepEqual(m, expected) {
+		t.Errorf("raw genesis: expected %v, got %v", expected, m)
+	}
+
+	m := &SlpMint{TokenID: []byte{0x01}, MintBatonVout: 2, Qty: 5}
+	expected = map[string]string{"token_id": "01", "mint_baton_vout": "2", "additional_qty": "5"}
+	if v := m.ToMap(false); !reflect.DeepEqual(v, expected) {
+		t.Errorf("mint: expected %v, got %v", expected, v)
+	}
+
+	s := &SlpSend{TokenID: []byte{0x01}, Amounts: []uint64{1, 0, 20}}
+	expected = map[string]string{"token_id": "01", "token_amounts": "1,0,20"}
+	if v := s.ToMap(false); !reflect.DeepEqual(v, expected) {
+		t.Errorf("send: expected %v, got %v", expected, v)
+	}
+}
@@ -0,0 +1,7 @@
+// Package normutil provides unicode normalization of SLP GENESIS text fields,
+// so that registries can compare tickers which are encoded differently but
+// render the same.
+//
+// These are kept separate from the parser package so that the parser does
+// not depend on golang.org/x/text. Only this package imports it.
+package normutil
@@ -0,0 +1,16 @@
+package normutil
+
+import (
+	"github.com/blockparty-sh/GoSlp/parser"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizedTicker returns the ticker of g converted to the normalization
+// form, such as norm.NFC. Invalid utf8 is passed through unchanged. The raw
+// ticker bytes are left as they are in g.
+//
+// Normalization does not detect lookalike characters from different scripts,
+// so tickers which normalize differently may still appear the same.
+func NormalizedTicker(g *parser.SlpGenesis, form norm.Form) string {
+	return form.String(string(g.Ticker))
+}
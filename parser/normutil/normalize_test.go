@@ -0,0 +1,35 @@
+package normutil
+
+import (
+	"testing"
+
+	"github.com/blockparty-sh/GoSlp/parser"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestNormalizedTicker(t *testing.T) {
+	// an accented ticker with a precomposed E acute, and with E followed by a
+	// combining acute accent
+	composed := &parser.SlpGenesis{Ticker: []byte("CAF\u00c9")}
+	decomposed := &parser.SlpGenesis{Ticker: []byte("CAFE\u0301")}
+
+	if string(composed.Ticker) == string(decomposed.Ticker) {
+		t.Fatal("expected raw tickers to differ")
+	}
+
+	for _, form := range []norm.Form{norm.NFC, norm.NFD, norm.NFKC, norm.NFKD} {
+		a := NormalizedTicker(composed, form)
+		b := NormalizedTicker(decomposed, form)
+		if a != b {
+			t.Errorf("form %d: expected equal normalized tickers, got %q and %q", form, a, b)
+		}
+	}
+
+	if v := NormalizedTicker(decomposed, norm.NFC); v != "CAF\u00c9" {
+		t.Errorf("expected NFC ticker %q, got %q", "CAF\u00c9", v)
+	}
+
+	if string(decomposed.Ticker) != "CAFE\u0301" {
+		t.Error("expected raw ticker to be unchanged")
+	}
+}
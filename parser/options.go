@@ -0,0 +1,211 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TokenType is the token_type field of an SLP message
+type TokenType int
+
+const (
+	// TokenTypeFungible is a token-type1 fungible token
+	TokenTypeFungible TokenType = 0x01
+	// TokenTypeNft1Child is an NFT1 Child token
+	TokenTypeNft1Child TokenType = 0x41
+	// TokenTypeNft1Group is an NFT1 Group token
+	TokenTypeNft1Group TokenType = 0x81
+)
+
+// IsNFT returns true if t is an NFT1 Group or NFT1 Child token type
+func (t TokenType) IsNFT() bool {
+	return t == TokenTypeNft1Group || t == TokenTypeNft1Child
+}
+
+// IsFungible returns true if t is the token-type1 fungible token type
+func (t TokenType) IsFungible() bool {
+	return t == TokenTypeFungible
+}
+
+// TransactionType is the transaction_type field of an SLP message
+type TransactionType string
+
+const (
+	// TransactionTypeGenesis creates a new token
+	TransactionTypeGenesis TransactionType = "GENESIS"
+	// TransactionTypeMint creates additional quantity of an existing token
+	TransactionTypeMint TransactionType = "MINT"
+	// TransactionTypeSend transfers tokens to transaction outputs
+	TransactionTypeSend TransactionType = "SEND"
+)
+
+// messageFields holds the field name of each chunk of a message, by
+// transaction type. A SEND lists only its first token_amount.
+var messageFields = map[TransactionType][]string{
+	TransactionTypeGenesis: {"lokad_id", "token_type", "transaction_type", "ticker", "name",
+		"document_uri", "document_hash", "decimals", "mint_baton_vout", "initial_qty"},
+	TransactionTypeMint: {"lokad_id", "token_type", "transaction_type", "token_id", "mint_baton_vout",
+		"additional_qty"},
+	TransactionTypeSend: {"lokad_id", "token_type", "transaction_type", "token_id", "token_amount"},
+}
+
+// ExpectedChunkCount returns the number of chunks, including the lokad id,
+// required by a message of txType. exact is true if the message must have
+// exactly min chunks, and false if min is only the minimum, as for SEND which
+// may carry up to 19 amounts after its first. min is 0 for an unknown
+// transaction type.
+//
+// The minimum for SEND is 5 since the SLP spec requires at least one
+// token_amount after the 4 chunks ending in the token_id. The original parser
+// only checked for those 4, leaving the missing amount to be caught later.
+func ExpectedChunkCount(txType string) (min int, exact bool) {
+	fields, ok := messageFields[TransactionType(txType)]
+	return len(fields), ok && TransactionType(txType) != TransactionTypeSend
+}
+
+// ErrWrongChunkCount is the reason given in a ParseError for a GENESIS or
+// MINT message with more chunks than ExpectedChunkCount. The ChunkIndex of
+// the error is the number of chunks in the message.
+var ErrWrongChunkCount = errors.New("wrong number of chunks")
+
+// checkChunkCount checks that a message of txType has the number of chunks
+// given by ExpectedChunkCount. A message which is too short is reported at
+// its first missing chunk.
+func checkChunkCount(txType string, chunkCount int) error {
+	min, exact := ExpectedChunkCount(txType)
+	if chunkCount < min {
+		field := messageFields[TransactionType(txType)][chunkCount]
+		if field == "token_amount" {
+			return chunkCheckReason(true, chunkCount, field, ErrNoSendAmounts)
+		}
+		return endedEarly(chunkCount, field)
+	}
+
+	if exact && chunkCount != min {
+		return &ParseError{
+			ChunkIndex: chunkCount,
+			Reason:     fmt.Errorf("%w: expected %d, got %d", ErrWrongChunkCount, min, chunkCount),
+		}
+	}
+
+	return nil
+}
+
+// ErrInvalidUtf8 is returned when ParseOptions.RequireValidUtf8 is set and a
+// GENESIS text field is not valid utf8
+var ErrInvalidUtf8 = errors.New("field is not valid utf8")
+
+// DefaultMaxSendOutputs is the maximum number of amounts in a SEND message.
+// A SEND with 19 amounts fills the 223 byte OP_RETURN relay limit.
+const DefaultMaxSendOutputs = 19
+
+// ErrTooManyOutputs is returned for a SEND message with more amounts than
+// ParseOptions.MaxSendOutputs, which defaults to the SLP limit of 19
+var ErrTooManyOutputs = errors.New("too many send outputs")
+
+// ErrTokenTypeNotAllowed is returned when a message has a token type which is
+// not listed in ParseOptions.AllowedTokenTypes
+var ErrTokenTypeNotAllowed = errors.New("token_type not allowed")
+
+// ErrRepeatedLokad is returned when ParseOptions.RejectRepeatedLokad is set
+// and the lokad id is pushed again after the first chunk
+var ErrRepeatedLokad = errors.New("lokad id repeated after first chunk")
+
+// ErrNonMinimalPush is returned when ParseOptions.RequireMinimalPush is set
+// and a chunk was not pushed using the smallest possible opcode
+var ErrNonMinimalPush = errors.New("pushdata not minimally encoded")
+
+// ParseOptions controls optional restrictions applied by ParseSLPWithOptions.
+// The zero value applies no additional restrictions.
+type ParseOptions struct {
+	// AllowedTokenTypes restricts which token types parse successfully.
+	// If empty, all token types are allowed.
+	AllowedTokenTypes []TokenType
+
+	// RequireMinimalPush rejects chunks which could have been pushed with a
+	// smaller opcode. Empty chunks must be pushed as OP_PUSHDATA1 with a
+	// length of 0, since SLP does not allow OP_0.
+	RequireMinimalPush bool
+
+	// MaxSendOutputs is the maximum number of amounts allowed in a SEND
+	// message. If 0, DefaultMaxSendOutputs is used. Larger values are only
+	// useful for testing, since such messages exceed the OP_RETURN relay limit.
+	MaxSendOutputs int
+
+	// RequireValidUtf8 rejects GENESIS messages whose ticker, name, or
+	// documentURI is not valid utf8. The SLP spec allows any bytes.
+	RequireValidUtf8 bool
+
+	// ExtraTokenTypes are accepted in addition to the token types defined by
+	// the SLP spec, allowing experimental token types to be parsed. They are
+	// parsed using the token-type1 rules.
+	ExtraTokenTypes []TokenType
+
+	// RejectRepeatedLokad rejects scripts where any chunk after the first is
+	// also the SLP lokad id with ErrRepeatedLokad. Such scripts are valid SLP,
+	// but may be flagged by strict validators.
+	RejectRepeatedLokad bool
+
+	// AllowTrailingData accepts scripts with bytes following the last push
+	// instead of rejecting them, returning the bytes in
+	// ParseResult.TrailingBytes. The codec must report them with a
+	// TrailingDataError. Encoding the result drops the trailing bytes.
+	AllowTrailingData bool
+
+	// RelaxNftChildRules accepts NFT1 Child GENESIS messages which break the
+	// quantity, decimals, or mint baton rules, recording the violation in
+	// ParseResult.NftChildRuleErr. This is for archival tools recording
+	// messages as they appeared on chain. Such results fail to Encode.
+	RelaxNftChildRules bool
+
+	// Codec decodes the pushdata chunks of the script. If nil, a BCHCodec
+	// with RequireMinimalPush set from these options is used. A custom codec
+	// is responsible for its own minimal push checks.
+	Codec ScriptCodec
+
+	// OnResult, if set, is called at the end of every parse with the
+	// transaction type and the error returned, if any. txType is empty if
+	// parsing failed before a known transaction type was read. This can be
+	// used to collect metrics on parse outcomes.
+	OnResult func(txType TransactionType, err error)
+}
+
+func (o *ParseOptions) codec() ScriptCodec {
+	if o.Codec != nil {
+		return o.Codec
+	}
+
+	return BCHCodec{RequireMinimalPush: o.RequireMinimalPush}
+}
+
+func (o *ParseOptions) maxSendOutputs() int {
+	if o.MaxSendOutputs == 0 {
+		return DefaultMaxSendOutputs
+	}
+
+	return o.MaxSendOutputs
+}
+
+func (o *ParseOptions) isExtraTokenType(tokenType TokenType) bool {
+	for _, t := range o.ExtraTokenTypes {
+		if t == tokenType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *ParseOptions) tokenTypeAllowed(tokenType TokenType) bool {
+	if len(o.AllowedTokenTypes) == 0 {
+		return true
+	}
+
+	for _, t := range o.AllowedTokenTypes {
+		if t == tokenType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,614 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParseSLPWithOptionsAllowedTokenTypes(t *testing.T) {
+	sendScript := func(tokenType byte) []byte {
+		return buildScript(
+			[]byte("SLP\x00"),
+			[]byte{tokenType},
+			[]byte("SEND"),
+			make([]byte, 32),
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		)
+	}
+
+	fungibleOnly := ParseOptions{AllowedTokenTypes: []TokenType{TokenTypeFungible}}
+	nftOnly := ParseOptions{AllowedTokenTypes: []TokenType{TokenTypeNft1Group, TokenTypeNft1Child}}
+
+	tests := []struct {
+		name      string
+		tokenType byte
+		opts      ParseOptions
+		allowed   bool
+	}{
+		{"default fungible", 0x01, ParseOptions{}, true},
+		{"default nft1 group", 0x81, ParseOptions{}, true},
+		{"default nft1 child", 0x41, ParseOptions{}, true},
+		{"fungible only fungible", 0x01, fungibleOnly, true},
+		{"fungible only nft1 group", 0x81, fungibleOnly, false},
+		{"fungible only nft1 child", 0x41, fungibleOnly, false},
+		{"nft only fungible", 0x01, nftOnly, false},
+		{"nft only nft1 group", 0x81, nftOnly, true},
+		{"nft only nft1 child", 0x41, nftOnly, true},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSLPWithOptions(sendScript(test.tokenType), test.opts)
+		if test.allowed && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if !test.allowed && !errors.Is(err, ErrTokenTypeNotAllowed) {
+			t.Errorf("%s: expected ErrTokenTypeNotAllowed, got %v", test.name, err)
+		}
+	}
+}
+
+func TestParseSLPWithOptionsRequireMinimalPush(t *testing.T) {
+	// push encodes data using the given opcode
+	push := func(opcode byte, data []byte) []byte {
+		l := len(data)
+		var script []byte
+		switch opcode {
+		case 0x4c:
+			script = []byte{0x4c, byte(l)}
+		case 0x4d:
+			script = []byte{0x4d, byte(l), byte(l >> 8)}
+		case 0x4e:
+			script = []byte{0x4e, byte(l), byte(l >> 8), byte(l >> 16), byte(l >> 24)}
+		default:
+			script = []byte{opcode}
+		}
+		return append(script, data...)
+	}
+
+	genesisScript := func(nameOpcode byte, nameLen int) []byte {
+		script := buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("GENESIS"),
+			[]byte("TOK"),
+		)
+		script = append(script, push(nameOpcode, make([]byte, nameLen))...)
+		return append(script, buildScript(
+			[]byte{},
+			[]byte{},
+			[]byte{0x00},
+			[]byte{},
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		)[1:]...)
+	}
+
+	tests := []struct {
+		name    string
+		opcode  byte
+		nameLen int
+		minimal bool
+	}{
+		{"empty pushdata1", 0x4c, 0, true},
+		{"empty pushdata2", 0x4d, 0, false},
+		{"75 direct", 75, 75, true},
+		{"75 pushdata1", 0x4c, 75, false},
+		{"76 pushdata1", 0x4c, 76, true},
+		{"255 pushdata1", 0x4c, 255, true},
+		{"255 pushdata2", 0x4d, 255, false},
+		{"256 pushdata2", 0x4d, 256, true},
+		{"65535 pushdata2", 0x4d, 65535, true},
+		{"65535 pushdata4", 0x4e, 65535, false},
+		{"65536 pushdata4", 0x4e, 65536, true},
+	}
+
+	for _, test := range tests {
+		script := genesisScript(test.opcode, test.nameLen)
+
+		if _, err := ParseSLP(script); err != nil {
+			t.Errorf("%s: unexpected error without RequireMinimalPush: %v", test.name, err)
+		}
+
+		_, err := ParseSLPWithOptions(script, ParseOptions{RequireMinimalPush: true})
+		if test.minimal && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if !test.minimal && !errors.Is(err, ErrNonMinimalPush) {
+			t.Errorf("%s: expected ErrNonMinimalPush, got %v", test.name, err)
+		}
+	}
+}
+
+func TestParseSLPWithOptionsOnResult(t *testing.T) {
+	var (
+		gotType TransactionType
+		gotErr  error
+		calls   int
+	)
+	opts := ParseOptions{
+		OnResult: func(txType TransactionType, err error) {
+			gotType = txType
+			gotErr = err
+			calls++
+		},
+	}
+
+	tests := []struct {
+		name     string
+		script   []byte
+		txType   TransactionType
+		hasError bool
+	}{
+		{
+			"valid send",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), make([]byte, 8)),
+			TransactionTypeSend,
+			false,
+		},
+		{
+			"malformed mint",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("MINT"), make([]byte, 31), []byte{}, make([]byte, 8)),
+			TransactionTypeMint,
+			true,
+		},
+		{
+			"unknown transaction type",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("BURN"), make([]byte, 32)),
+			"",
+			true,
+		},
+		{
+			"non-slp",
+			buildScript([]byte("ABC\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32)),
+			"",
+			true,
+		},
+	}
+
+	for i, test := range tests {
+		_, err := ParseSLPWithOptions(test.script, opts)
+		if calls != i+1 {
+			t.Fatalf("%s: expected OnResult to be called once per parse", test.name)
+		}
+		if gotType != test.txType {
+			t.Errorf("%s: expected transaction type %q, got %q", test.name, test.txType, gotType)
+		}
+		if gotErr != err {
+			t.Errorf("%s: expected OnResult error %v, got %v", test.name, err, gotErr)
+		}
+		if (err != nil) != test.hasError {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+	}
+}
+
+func TestParseSLPWithOptionsMaxSendOutputs(t *testing.T) {
+	sendScript := func(outputs int) []byte {
+		chunks := [][]byte{
+			[]byte("SLP\x00"),
+			{0x01},
+			[]byte("SEND"),
+			make([]byte, 32),
+		}
+		for i := 0; i < outputs; i++ {
+			chunks = append(chunks, make([]byte, 8))
+		}
+		return buildScript(chunks...)
+	}
+
+	tests := []struct {
+		name    string
+		outputs int
+		opts    ParseOptions
+		valid   bool
+	}{
+		{"19 outputs default", 19, ParseOptions{}, true},
+		{"20 outputs default", 20, ParseOptions{}, false},
+		{"20 outputs with max 20", 20, ParseOptions{MaxSendOutputs: 20}, true},
+		{"21 outputs with max 20", 21, ParseOptions{MaxSendOutputs: 20}, false},
+		{"3 outputs with max 2", 3, ParseOptions{MaxSendOutputs: 2}, false},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSLPWithOptions(sendScript(test.outputs), test.opts)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+
+	if _, err := ParseSLP(sendScript(20)); err == nil {
+		t.Error("expected ParseSLP to reject 20 outputs")
+	}
+}
+
+func TestTokenTypePredicates(t *testing.T) {
+	tests := []struct {
+		tokenType TokenType
+		nft       bool
+		fungible  bool
+	}{
+		{TokenTypeFungible, false, true},
+		{TokenTypeNft1Child, true, false},
+		{TokenTypeNft1Group, true, false},
+		{0x00, false, false},
+		{0x02, false, false},
+	}
+
+	for _, test := range tests {
+		if v := test.tokenType.IsNFT(); v != test.nft {
+			t.Errorf("TokenType(0x%02x).IsNFT() = %v, expected %v", int(test.tokenType), v, test.nft)
+		}
+		if v := test.tokenType.IsFungible(); v != test.fungible {
+			t.Errorf("TokenType(0x%02x).IsFungible() = %v, expected %v", int(test.tokenType), v, test.fungible)
+		}
+	}
+}
+
+func TestParseSLPWithOptionsAllowTrailingData(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+	trailing := []byte{0x00, 0x51, 0xac}
+	script = append(script, trailing...)
+
+	_, err := ParseSLP(script)
+	var trailingErr *TrailingDataError
+	if !errors.As(err, &trailingErr) {
+		t.Fatalf("strict: expected TrailingDataError, got %v", err)
+	}
+	if !bytes.Equal(trailingErr.Data, trailing) {
+		t.Errorf("strict: expected trailing data %x, got %x", trailing, trailingErr.Data)
+	}
+
+	r, err := ParseSLPWithOptions(script, ParseOptions{AllowTrailingData: true})
+	if err != nil {
+		t.Fatalf("lenient: unexpected error %v", err)
+	}
+	if r.TransactionType != "SEND" {
+		t.Errorf("lenient: expected SEND, got %s", r.TransactionType)
+	}
+	if !bytes.Equal(r.TrailingBytes, trailing) {
+		t.Errorf("lenient: expected trailing bytes %x, got %x", trailing, r.TrailingBytes)
+	}
+
+	// scripts without trailing data are unaffected
+	r, err = ParseSLPWithOptions(script[:len(script)-len(trailing)], ParseOptions{AllowTrailingData: true})
+	if err != nil {
+		t.Fatalf("lenient without trailing data: unexpected error %v", err)
+	}
+	if r.TrailingBytes != nil {
+		t.Errorf("lenient without trailing data: expected nil trailing bytes, got %x", r.TrailingBytes)
+	}
+}
+
+func TestParseSLPWithOptionsRejectRepeatedLokad(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		[]byte("SLP\x00"),
+	)
+
+	_, err := ParseSLPWithOptions(script, ParseOptions{RejectRepeatedLokad: true})
+	var parseErr *ParseError
+	if !errors.Is(err, ErrRepeatedLokad) || !errors.As(err, &parseErr) || parseErr.ChunkIndex != 5 {
+		t.Errorf("expected ErrRepeatedLokad at chunk 5, got %v", err)
+	}
+
+	// without the option the amount size check rejects it instead
+	if _, err := ParseSLP(script); err == nil || errors.Is(err, ErrRepeatedLokad) {
+		t.Errorf("expected amount error, got %v", err)
+	}
+
+	valid := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), []byte{0, 0, 0, 0, 0, 0, 0, 1})
+	if _, err := ParseSLPWithOptions(valid, ParseOptions{RejectRepeatedLokad: true}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestExpectedChunkCount(t *testing.T) {
+	tests := []struct {
+		txType string
+		min    int
+		exact  bool
+	}{
+		{"GENESIS", 10, true},
+		{"MINT", 6, true},
+		{"SEND", 5, false},
+		{"BURN", 0, false},
+		{"send", 0, false},
+	}
+
+	for _, test := range tests {
+		min, exact := ExpectedChunkCount(test.txType)
+		if min != test.min || exact != test.exact {
+			t.Errorf("%s: expected (%d, %v), got (%d, %v)", test.txType, test.min, test.exact, min, exact)
+		}
+	}
+}
+
+func TestParseChunkCount(t *testing.T) {
+	tokenID := make([]byte, 32)
+	amount := []byte{0, 0, 0, 0, 0, 0, 0, 1}
+	mint := [][]byte{[]byte("SLP\x00"), {0x01}, []byte("MINT"), tokenID, {0x02}, amount}
+	send := [][]byte{[]byte("SLP\x00"), {0x01}, []byte("SEND"), tokenID, amount}
+
+	genesis := [][]byte{[]byte("SLP\x00"), {0x01}, []byte("GENESIS"), {}, {}, {}, {}, {0x00}, {}, amount}
+
+	tests := []struct {
+		name   string
+		chunks [][]byte
+		index  int
+		field  string
+		reason error
+	}{
+		{"genesis extra chunk", append(genesis[:10:10], amount), 11, "", ErrWrongChunkCount},
+		{"mint missing qty", mint[:5], 5, "additional_qty", ErrParsingEndedEarly},
+		{"mint extra chunk", append(mint[:6:6], amount), 7, "", ErrWrongChunkCount},
+		{"mint bad token_id", [][]byte{mint[0], mint[1], mint[2], tokenID[1:]}, 3, "token_id", nil},
+		{"send missing amount", send[:4], 4, "token_amount", ErrNoSendAmounts},
+		{"send missing token_id", send[:3], 3, "token_id", ErrParsingEndedEarly},
+		{"send bad token_id", [][]byte{send[0], send[1], send[2], tokenID[1:]}, 3, "token_id", nil},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSLP(buildScript(test.chunks...))
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.ChunkIndex != test.index || parseErr.Field != test.field {
+			t.Errorf("%s: expected chunk %d (%s) error, got %v", test.name, test.index, test.field, err)
+			continue
+		}
+		if test.reason != nil && !errors.Is(err, test.reason) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.reason, err)
+		}
+		if test.reason == nil && (errors.Is(err, ErrParsingEndedEarly) || errors.Is(err, ErrNoSendAmounts)) {
+			t.Errorf("%s: expected token_id checked before the chunk count, got %v", test.name, err)
+		}
+	}
+}
+
+func TestParseSLPWithOptionsRequireValidUtf8(t *testing.T) {
+	genesisScript := func(ticker, name, documentURI []byte) []byte {
+		return buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("GENESIS"),
+			ticker,
+			name,
+			documentURI,
+			[]byte{},
+			[]byte{0x00},
+			[]byte{},
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		)
+	}
+
+	invalid := []byte{'T', 0xff, 0xfe}
+	tests := []struct {
+		name   string
+		script []byte
+		field  string
+	}{
+		{"valid", genesisScript([]byte("TOK"), []byte("Tökén"), []byte("https://simpleledger.cash")), ""},
+		{"invalid ticker", genesisScript(invalid, []byte("Token"), []byte{}), "ticker"},
+		{"invalid name", genesisScript([]byte("TOK"), invalid, []byte{}), "name"},
+		{"invalid documentURI", genesisScript([]byte("TOK"), []byte("Token"), invalid), "document_uri"},
+	}
+
+	for _, test := range tests {
+		r, err := ParseSLP(test.script)
+		if err != nil {
+			t.Errorf("%s: unexpected error in lenient mode %v", test.name, err)
+			continue
+		}
+
+		g := r.Data.(*SlpGenesis)
+		valid := g.TickerIsValidUtf8() && g.NameIsValidUtf8() && g.DocumentURIIsValidUtf8()
+		if valid != (test.field == "") {
+			t.Errorf("%s: expected IsValidUtf8 helpers to report %v", test.name, test.field == "")
+		}
+
+		_, err = ParseSLPWithOptions(test.script, ParseOptions{RequireValidUtf8: true})
+		if test.field == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", test.name, err)
+			}
+			continue
+		}
+
+		var parseErr *ParseError
+		if !errors.Is(err, ErrInvalidUtf8) || !errors.As(err, &parseErr) || parseErr.Field != test.field {
+			t.Errorf("%s: expected ErrInvalidUtf8 for %s, got %v", test.name, test.field, err)
+		}
+	}
+}
+
+func TestParseSLPWithOptionsExtraTokenTypes(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x02},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	if _, err := ParseSLP(script); err == nil {
+		t.Error("expected token type 0x02 to be rejected by default")
+	}
+
+	if _, err := ParseSLPWithOptions(script, ParseOptions{ExtraTokenTypes: []TokenType{0x03}}); err == nil {
+		t.Error("expected token type 0x02 to be rejected when not listed")
+	}
+
+	r, err := ParseSLPWithOptions(script, ParseOptions{ExtraTokenTypes: []TokenType{0x02}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TokenType != 0x02 {
+		t.Errorf("expected token type 0x02, got 0x%02x", r.TokenType)
+	}
+
+	opts := ParseOptions{
+		ExtraTokenTypes:   []TokenType{0x02},
+		AllowedTokenTypes: []TokenType{TokenTypeFungible},
+	}
+	if _, err := ParseSLPWithOptions(script, opts); !errors.Is(err, ErrTokenTypeNotAllowed) {
+		t.Errorf("expected ErrTokenTypeNotAllowed, got %v", err)
+	}
+}
+
+func TestIsCanonical(t *testing.T) {
+	canonical := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	if ok, err := IsCanonical(canonical); !ok || err != nil {
+		t.Errorf("expected canonical send, got %v, %v", ok, err)
+	}
+
+	// push the transaction type using OP_PUSHDATA1
+	nonMinimal := buildScript([]byte("SLP\x00"), []byte{0x01})
+	nonMinimal = append(nonMinimal, 0x4c, 0x04, 'S', 'E', 'N', 'D')
+	nonMinimal = append(nonMinimal, buildScript(make([]byte, 32), []byte{0, 0, 0, 0, 0, 0, 0, 1})[1:]...)
+
+	if _, err := ParseSLP(nonMinimal); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := IsCanonical(nonMinimal); ok || err != nil {
+		t.Errorf("expected non-canonical send, got %v, %v", ok, err)
+	}
+
+	if ok, err := IsCanonical(append(canonical, 0x00)); ok || err == nil {
+		t.Errorf("expected error for trailing data, got %v, %v", ok, err)
+	}
+}
+
+func TestCanonicalizeScript(t *testing.T) {
+	chunks := [][]byte{
+		[]byte("SLP\x00"),
+		{0x00, 0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		{0, 0, 0, 0, 0, 0, 0, 1},
+	}
+	canonical := buildScript(chunks...)
+
+	out, err := CanonicalizeScript(canonical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, canonical) {
+		t.Errorf("expected canonical script to be unchanged, got %x", out)
+	}
+
+	// push the lokad id with OP_PUSHDATA1
+	nonMinimal := append([]byte{0x6a, 0x4c, 0x04}, canonical[2:]...)
+	if ok, err := IsCanonical(nonMinimal); ok || err != nil {
+		t.Fatalf("expected non-minimal script, got %v %v", ok, err)
+	}
+
+	out, err = CanonicalizeScript(nonMinimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, canonical) || len(out) != len(nonMinimal)-1 {
+		t.Errorf("expected %x, got %x", canonical, out)
+	}
+
+	if _, err := CanonicalizeScript(canonical[:len(canonical)-1]); err == nil {
+		t.Error("expected error for invalid script")
+	}
+}
+
+func TestParseSLPWithOptionsRelaxNftChildRules(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x41},
+		[]byte("GENESIS"),
+		[]byte("NFT"),
+		[]byte("My NFT"),
+		[]byte{},
+		[]byte{},
+		[]byte{0x00},
+		[]byte{},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 5},
+	)
+
+	if _, err := ParseSLP(script); err == nil {
+		t.Error("expected qty 5 NFT1 child genesis to be rejected by default")
+	}
+
+	r, err := ParseSLPWithOptions(script, ParseOptions{RelaxNftChildRules: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, _ := r.AsGenesis(); g.Qty != 5 {
+		t.Errorf("expected qty 5, got %d", g.Qty)
+	}
+	if !errors.Is(r.NftChildRuleErr, ErrNft1ChildQty) {
+		t.Errorf("expected NftChildRuleErr to match ErrNft1ChildQty, got %v", r.NftChildRuleErr)
+	}
+	if errors.Is(r.NftChildRuleErr, ErrNft1ChildDecimals) || errors.Is(r.NftChildRuleErr, ErrNft1ChildMintBaton) {
+		t.Errorf("expected only the qty rule to fail, got %v", r.NftChildRuleErr)
+	}
+
+	// every broken rule is reported
+	everyRule := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x41},
+		[]byte("GENESIS"),
+		[]byte("NFT"),
+		[]byte("My NFT"),
+		[]byte{},
+		[]byte{},
+		[]byte{0x02},
+		[]byte{0x02},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 5},
+	)
+	r, err = ParseSLPWithOptions(everyRule, ParseOptions{RelaxNftChildRules: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, target := range []error{ErrNft1ChildDecimals, ErrNft1ChildMintBaton, ErrNft1ChildQty} {
+		if !errors.Is(r.NftChildRuleErr, target) {
+			t.Errorf("expected NftChildRuleErr to match %v, got %v", target, r.NftChildRuleErr)
+		}
+	}
+	if _, err := ParseSLP(everyRule); !errors.Is(err, ErrNft1ChildDecimals) || !errors.Is(err, ErrNft1ChildQty) {
+		t.Errorf("strict: expected every broken rule, got %v", err)
+	}
+
+	valid := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x41},
+		[]byte("GENESIS"),
+		[]byte("NFT"),
+		[]byte("My NFT"),
+		[]byte{},
+		[]byte{},
+		[]byte{0x00},
+		[]byte{},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+	r, err = ParseSLPWithOptions(valid, ParseOptions{RelaxNftChildRules: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.NftChildRuleErr != nil {
+		t.Errorf("expected no NftChildRuleErr for a valid child, got %v", r.NftChildRuleErr)
+	}
+}
@@ -1,12 +1,97 @@
 package parser
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"math/big"
+	"net/url"
+	"unicode/utf8"
 )
 
+// lokadID is the prefix pushed as the first chunk of every SLP OP_RETURN
+var lokadID = []byte{'S', 'L', 'P', 0x00}
+
+// LokadID returns a copy of the prefix pushed as the first chunk of every SLP
+// OP_RETURN
+func LokadID() []byte {
+	return cloneBytes(lokadID)
+}
+
+// MaxSlpAmount is the largest amount representable in an SLP message
+const MaxSlpAmount uint64 = math.MaxUint64
+
+// ErrAmountOverflow is returned when a sum of amounts exceeds MaxSlpAmount
+var ErrAmountOverflow = errors.New("amount sum exceeds MaxSlpAmount")
+
+// ErrSupplyOverflow is returned when minting would take a token's supply
+// above MaxSlpAmount
+var ErrSupplyOverflow = errors.New("supply exceeds MaxSlpAmount")
+
+// ErrEmptyDocumentURI is returned by ParsedDocumentURI when the genesis
+// message does not carry a documentURI
+var ErrEmptyDocumentURI = errors.New("documentURI is empty")
+
+// The following errors are returned for scripts which are not SLP messages at
+// all, before the lokad id has been matched. Any other parse error means the
+// script claimed to be SLP but was malformed, so callers indexing every
+// OP_RETURN can use errors.Is with these to separate the two.
+var (
+	// ErrEmptyScript is returned for an empty scriptPubKey
+	ErrEmptyScript = errors.New("scriptpubkey cannot be empty")
+
+	// ErrTooSmall is returned for a scriptPubKey too short to hold any SLP
+	// message
+	ErrTooSmall = errors.New("scriptpubkey too small")
+
+	// ErrNotOpReturn is returned when the scriptPubKey does not start with
+	// OP_RETURN. The OP_RETURN must be the first byte, so scripts with any
+	// prefix, such as the OP_0 OP_RETURN form, are rejected.
+	ErrNotOpReturn = errors.New("scriptpubkey not op_return")
+
+	// ErrEmptyFirstPush is returned when the OP_RETURN is followed by an empty
+	// push, either OP_0 or OP_PUSHDATA1 with a length of 0, instead of the
+	// lokad id
+	ErrEmptyFirstPush = errors.New("first push after op_return is empty")
+
+	// ErrNotSLP is returned when the first push is not the SLP lokad id
+	ErrNotSLP = errors.New("SLP not in first chunk")
+)
+
+// ErrParsingEndedEarly is returned when a message has fewer chunks than its
+// transaction type requires
+var ErrParsingEndedEarly = errors.New("parsing ended early")
+
+// ErrNft1ChildCannotMint is returned for a MINT message of an NFT1 Child token
+var ErrNft1ChildCannotMint = errors.New("NFT1 Child cannot have MINT transaction type")
+
+// ErrNoSendAmounts is returned for a SEND message without any token_amount
+var ErrNoSendAmounts = errors.New("token_amounts size is 0")
+
+// ErrAmountLength is returned when a SEND token_amount or MINT additional_qty
+// chunk is not 8 bytes
+var ErrAmountLength = errors.New("amount must be 8 bytes")
+
+// ErrQtyLength is returned when a GENESIS initial_qty chunk is not 8 bytes
+var ErrQtyLength = errors.New("initialQty must be 8 bytes")
+
+// ErrDecimalsLength is returned when a GENESIS decimals chunk is not 1 byte
+var ErrDecimalsLength = errors.New("decimals string length must be 1")
+
+// ErrDocumentHashSize is returned when a GENESIS documentHash is not 0 or 32 bytes
+var ErrDocumentHashSize = errors.New("documentHash must be size 0 or 32")
+
 // SlpGenesis is an unmarshalled Genesis OP_RETURN
+//
+// The parser always sets Ticker, Name, DocumentURI, and DocumentHash to a
+// non-nil slice, so an empty field is represented by a zero-length slice
+// rather than nil.
 type SlpGenesis struct {
 	Ticker, Name, DocumentURI, DocumentHash []byte
 	Decimals, MintBatonVout                 int
@@ -23,16 +108,137 @@ func (g *SlpGenesis) NameAsUtf8() string {
 	return string(g.Name)
 }
 
+// TickerIsValidUtf8 returns true if the ticker field is valid utf8
+func (g *SlpGenesis) TickerIsValidUtf8() bool {
+	return utf8.Valid(g.Ticker)
+}
+
+// NameIsValidUtf8 returns true if the name field is valid utf8
+func (g *SlpGenesis) NameIsValidUtf8() bool {
+	return utf8.Valid(g.Name)
+}
+
+// DocumentURIIsValidUtf8 returns true if the documentURI field is valid utf8
+func (g *SlpGenesis) DocumentURIIsValidUtf8() bool {
+	return utf8.Valid(g.DocumentURI)
+}
+
 // DocumentURIAsUtf8 converts documentURI field bytes to string using utf8 decoding
 func (g *SlpGenesis) DocumentURIAsUtf8() string {
 	return string(g.DocumentURI)
 }
 
+// ParsedDocumentURI parses the utf8 decoded documentURI field using net/url.
+// ErrEmptyDocumentURI is returned if the field is empty, and an error is
+// returned if the field is not an absolute URI (one that includes a scheme,
+// such as https:// or ipfs://)
+func (g *SlpGenesis) ParsedDocumentURI() (*url.URL, error) {
+	if len(g.DocumentURI) == 0 {
+		return nil, ErrEmptyDocumentURI
+	}
+
+	u, err := url.Parse(g.DocumentURIAsUtf8())
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.IsAbs() {
+		return nil, errors.New("documentURI is not an absolute URI")
+	}
+
+	return u, nil
+}
+
+// DocumentURIIsValid reports whether the documentURI field is a syntactically
+// valid absolute URI. An empty documentURI is not considered valid.
+func (g *SlpGenesis) DocumentURIIsValid() bool {
+	_, err := g.ParsedDocumentURI()
+	return err == nil
+}
+
+// HasConsistentDocument returns false if the genesis message has a
+// documentURI but no documentHash. This is a convention some token registries
+// require for complete metadata, it is not an SLP consensus rule.
+func (g *SlpGenesis) HasConsistentDocument() bool {
+	return len(g.DocumentURI) == 0 || len(g.DocumentHash) != 0
+}
+
 // DocumentHashAsHex converts documentHash field bytes to string using hexidecimal encoding
 func (g *SlpGenesis) DocumentHashAsHex() string {
 	return hex.EncodeToString(g.DocumentHash)
 }
 
+// MintBatonVoutPtr returns the mint baton vout, or nil if the message has no
+// mint baton. A MintBatonVout of 0 always means the mint_baton_vout chunk was
+// empty, since the parser rejects a pushed value below 2.
+func (g *SlpGenesis) MintBatonVoutPtr() *int {
+	return mintBatonVoutPtr(g.MintBatonVout)
+}
+
+// BatonVout returns the vout of the mint baton, and false if the message has
+// no mint baton
+func (g *SlpGenesis) BatonVout() (int, bool) {
+	return g.MintBatonVout, g.MintBatonVout != 0
+}
+
+// OutputMap returns the token amount received by each output: the initial
+// quantity at vout 1. The mint baton output, if any, carries no amount and is
+// not included.
+func (g *SlpGenesis) OutputMap() map[int]uint64 {
+	return map[int]uint64{1: g.Qty}
+}
+
+// IsFixedSupply returns true if the genesis has no mint baton, so no more of
+// the token can ever be minted
+func (g *SlpGenesis) IsFixedSupply() bool {
+	_, hasBaton := g.BatonVout()
+	return !hasBaton
+}
+
+// QtyBig returns Qty as a big.Int. The value is encoded as a uint64 in the
+// OP_RETURN, this is provided for summing quantities without overflow.
+func (g *SlpGenesis) QtyBig() *big.Int {
+	return new(big.Int).SetUint64(g.Qty)
+}
+
+// Equal returns true if both genesis messages contain the same values.
+// A nil byte slice is considered equal to an empty one.
+func (g *SlpGenesis) Equal(o SlpGenesis) bool {
+	return bytes.Equal(g.Ticker, o.Ticker) &&
+		bytes.Equal(g.Name, o.Name) &&
+		bytes.Equal(g.DocumentURI, o.DocumentURI) &&
+		bytes.Equal(g.DocumentHash, o.DocumentHash) &&
+		g.Decimals == o.Decimals &&
+		g.MintBatonVout == o.MintBatonVout &&
+		g.Qty == o.Qty
+}
+
+// ErrNoBaton is returned by NewMint for a genesis message without a mint
+// baton, since its token can never be minted
+var ErrNoBaton = errors.New("genesis has no mint baton")
+
+// NewMint returns the mint message creating additionalQty more of the token
+// created by the genesis, whose tokenID is the genesis txid. newBatonVout is
+// the vout to pass the mint baton to, or 0 to end minting. ErrNoBaton is
+// returned if the genesis had no mint baton.
+func (g *SlpGenesis) NewMint(tokenID []byte, additionalQty uint64, newBatonVout int) (SlpMint, error) {
+	if g.MintBatonVout == 0 {
+		return SlpMint{}, ErrNoBaton
+	}
+
+	m := SlpMint{
+		TokenID:       cloneBytes(tokenID),
+		MintBatonVout: newBatonVout,
+		Qty:           additionalQty,
+	}
+
+	if err := ValidateMint(m, 0x01); err != nil {
+		return SlpMint{}, err
+	}
+
+	return m, nil
+}
+
 // SlpMint is an unmarshalled Mint OP_RETURN
 type SlpMint struct {
 	TokenID       []byte
@@ -45,6 +251,56 @@ func (m *SlpMint) TokenIDAsHex() string {
 	return hex.EncodeToString(m.TokenID)
 }
 
+// MintBatonVoutPtr returns the mint baton vout, or nil if the message has no
+// mint baton. A MintBatonVout of 0 always means the mint_baton_vout chunk was
+// empty, since the parser rejects a pushed value below 2.
+func (m *SlpMint) MintBatonVoutPtr() *int {
+	return mintBatonVoutPtr(m.MintBatonVout)
+}
+
+// BatonVout returns the vout of the mint baton, and false if the message has
+// no mint baton
+func (m *SlpMint) BatonVout() (int, bool) {
+	return m.MintBatonVout, m.MintBatonVout != 0
+}
+
+// QtyBig returns Qty as a big.Int. The value is encoded as a uint64 in the
+// OP_RETURN, this is provided for summing quantities without overflow.
+func (m *SlpMint) QtyBig() *big.Int {
+	return new(big.Int).SetUint64(m.Qty)
+}
+
+// Equal returns true if both mint messages contain the same values
+func (m *SlpMint) Equal(o SlpMint) bool {
+	return bytes.Equal(m.TokenID, o.TokenID) &&
+		m.MintBatonVout == o.MintBatonVout &&
+		m.Qty == o.Qty
+}
+
+// OutputMap returns the token amount received by each output: the minted
+// quantity at vout 1. The mint baton output, if any, carries no amount and is
+// not included.
+func (m *SlpMint) OutputMap() map[int]uint64 {
+	return map[int]uint64{1: m.Qty}
+}
+
+// IsForToken returns true if the mint is for the token tokenID. A tokenID
+// which is not 32 bytes never matches.
+func (m *SlpMint) IsForToken(tokenID []byte) bool {
+	return checkValidTokenID(tokenID) && bytes.Equal(m.TokenID, tokenID)
+}
+
+// ApplyToSupply returns the supply of the token after the mint, given the
+// supply prior to it. ErrSupplyOverflow is returned if the new supply would
+// be larger than MaxSlpAmount.
+func (m *SlpMint) ApplyToSupply(prior uint64) (uint64, error) {
+	if m.Qty > MaxSlpAmount-prior {
+		return 0, ErrSupplyOverflow
+	}
+
+	return prior + m.Qty, nil
+}
+
 // SlpSend is an unmarshalled Send OP_RETURN
 type SlpSend struct {
 	TokenID []byte
@@ -56,464 +312,826 @@ func (s *SlpSend) TokenIDAsHex() string {
 	return hex.EncodeToString(s.TokenID)
 }
 
+// Equal returns true if both send messages contain the same values
+func (s *SlpSend) Equal(o SlpSend) bool {
+	if !bytes.Equal(s.TokenID, o.TokenID) || len(s.Amounts) != len(o.Amounts) {
+		return false
+	}
+
+	for i := range s.Amounts {
+		if s.Amounts[i] != o.Amounts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsForToken returns true if the send is for the token tokenID. A tokenID
+// which is not 32 bytes never matches.
+func (s *SlpSend) IsForToken(tokenID []byte) bool {
+	return checkValidTokenID(tokenID) && bytes.Equal(s.TokenID, tokenID)
+}
+
+// AmountForVout returns the amount sent to the transaction output vout.
+// Amounts start at vout 1 since vout 0 holds the OP_RETURN. false is returned
+// if vout has no SLP amount.
+func (s *SlpSend) AmountForVout(vout int) (uint64, bool) {
+	if vout < 1 || vout > len(s.Amounts) {
+		return 0, false
+	}
+
+	return s.Amounts[vout-1], true
+}
+
+// Outputs returns an iterator over the vout and amount of each output the
+// send assigns tokens to, starting at vout 1
+func (s *SlpSend) Outputs() iter.Seq2[int, uint64] {
+	return func(yield func(int, uint64) bool) {
+		for i, amount := range s.Amounts {
+			if !yield(i+1, amount) {
+				return
+			}
+		}
+	}
+}
+
+// OutputMap returns the token amount received by each output, mapping vout
+// i+1 to Amounts[i], including zero amounts
+func (s *SlpSend) OutputMap() map[int]uint64 {
+	outputs := make(map[int]uint64, len(s.Amounts))
+	for vout, amount := range s.Outputs() {
+		outputs[vout] = amount
+	}
+
+	return outputs
+}
+
+// AmountsBig returns Amounts as big.Ints. The values are encoded as uint64s
+// in the OP_RETURN, this is provided for summing amounts without overflow.
+func (s *SlpSend) AmountsBig() []*big.Int {
+	amounts := make([]*big.Int, len(s.Amounts))
+	for i, amount := range s.Amounts {
+		amounts[i] = new(big.Int).SetUint64(amount)
+	}
+	return amounts
+}
+
+// OutputAmountSum returns the total amount sent to all outputs, or
+// ErrAmountOverflow if the total is larger than MaxSlpAmount.
+//
+// The OP_RETURN does not contain the input amounts, so to detect a burn the
+// caller compares this total to the total of the token inputs it has found;
+// any input amount not sent to an output is burned.
+func (s *SlpSend) OutputAmountSum() (uint64, error) {
+	var total uint64
+	for _, amount := range s.Amounts {
+		if amount > MaxSlpAmount-total {
+			return 0, ErrAmountOverflow
+		}
+		total += amount
+	}
+	return total, nil
+}
+
+// ExceedsSupply returns true if the sum of all amounts is greater than supply.
+// A sum which overflows MaxSlpAmount always exceeds supply.
+func (s *SlpSend) ExceedsSupply(supply uint64) bool {
+	total, err := s.OutputAmountSum()
+	return err != nil || total > supply
+}
+
+// IsSingleOutput returns true if the send has exactly one amount, moving the
+// tokens to a single output without change
+func (s *SlpSend) IsSingleOutput() bool {
+	return len(s.Amounts) == 1
+}
+
+// TrailingZerosFrom returns true if every amount at index n or above is zero.
+// The parser cannot see the transaction's outputs, so a caller which knows
+// only n outputs follow the OP_RETURN can use this to check that no tokens
+// are assigned to outputs which do not exist.
+func (s *SlpSend) TrailingZerosFrom(n int) bool {
+	if n < 0 {
+		n = 0
+	}
+
+	for i := n; i < len(s.Amounts); i++ {
+		if s.Amounts[i] != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NonZeroCount returns the number of outputs assigned a non-zero amount
+func (s *SlpSend) NonZeroCount() int {
+	n := 0
+	for _, amount := range s.Amounts {
+		if amount != 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// FirstZeroIndex returns the index in Amounts of the first zero amount, or
+// -1 if every amount is non-zero
+func (s *SlpSend) FirstZeroIndex() int {
+	for i, amount := range s.Amounts {
+		if amount == 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// RecipientCount returns the number of outputs receiving tokens, excluding
+// the last output if lastIsChange is set. Whether the last output is change
+// cannot be known from the message alone, so this is only a heuristic for
+// display.
+func (s *SlpSend) RecipientCount(lastIsChange bool) int {
+	if lastIsChange && len(s.Amounts) > 0 {
+		return len(s.Amounts) - 1
+	}
+
+	return len(s.Amounts)
+}
+
+// ErrInvalidTokenID is returned by TokenIDFromHex for a string which is not
+// a 32 byte hex encoded tokenID
+var ErrInvalidTokenID = errors.New("tokenID must be 32 bytes hex encoded")
+
+// TokenIDFromHex converts a hexidecimal encoded tokenID, as returned by
+// TokenIDAsHex, to the byte form used by SlpMint and SlpSend
+func TokenIDFromHex(s string) ([]byte, error) {
+	tokenID, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTokenID, err)
+	}
+
+	if !checkValidTokenID(tokenID) {
+		return nil, ErrInvalidTokenID
+	}
+
+	return tokenID, nil
+}
+
 // SlpOpReturn represents a generic interface for
-// any type of unmarshalled SLP OP_RETURN message
+// any type of unmarshalled SLP OP_RETURN message.
+//
+// ParseResult.Data always holds a pointer: *SlpGenesis, *SlpMint, or
+// *SlpSend, matching the pointer receivers of their methods.
 type SlpOpReturn interface {
-	// TODO: once tests are added may need to add ToMap to simplify
-	//		 interaction with the SLP unit tests
-	//ToMap(raw bool) map[string]string
+	// ToMap returns the fields of the message as strings, as used to compare
+	// against the SLP unit test data
+	ToMap(raw bool) map[string]string
 }
 
 // ParseResult returns the parsed result.
+//
+// TokenType is one of 0x01 (token-type1), 0x81 (nft1-group), or 0x41
+// (nft1-child). An NFT1 Group token is used to create NFT1 Child tokens:
+// a child GENESIS must spend an output holding a quantity of the group
+// token, so group SEND outputs are what fund new children.
+//
+// TokenTypeBytes holds the token_type chunk as it appeared in the script,
+// since the token type may be pushed as either 1 or 2 bytes. TokenType is the
+// big endian value of the chunk, so 0x0041 and 0x41 are both NFT1 Child and
+// are checked identically, while 0x0100 is an unknown token type.
+//
+// Data holds a *SlpGenesis, *SlpMint, or *SlpSend for the transaction type.
+//
+// TrailingBytes holds any bytes following the last push of the script, which
+// are only accepted when ParseOptions.AllowTrailingData is set. They are not
+// written by Encode.
+//
+// NftChildRuleErr holds the errors for an NFT1 Child GENESIS which breaks the
+// quantity, decimals, or mint baton rules, joined so that each of
+// ErrNft1ChildQty, ErrNft1ChildDecimals, and ErrNft1ChildMintBaton can be
+// matched using errors.Is. It is only set when ParseOptions.RelaxNftChildRules
+// is set, since such messages are otherwise rejected.
 type ParseResult struct {
 	TokenType       int
+	TokenTypeBytes  []byte
 	TransactionType string
 	Data            SlpOpReturn
+	TrailingBytes   []byte
+	NftChildRuleErr error
 }
 
-// ParseSLP unmarshalls an SLP message from a transaction scriptPubKey.
-func ParseSLP(scriptPubKey []byte) (*ParseResult, error) {
-	it := 0
-	itObj := scriptPubKey
+// CanMintNftChildren returns true if the token is an NFT1 Group token,
+// meaning its outputs can be spent to create NFT1 Child tokens
+func (r *ParseResult) CanMintNftChildren() bool {
+	return r.TokenType == 0x81
+}
 
-	const OP_0 int = 0x00
-	const OP_RETURN int = 0x6a
-	const OP_PUSHDATA1 int = 0x4c
-	const OP_PUSHDATA2 int = 0x4d
-	const OP_PUSHDATA4 int = 0x4e
+// IsNft1ChildGenesis returns true if the result is the GENESIS of an NFT1
+// Child token.
+//
+// The OP_RETURN does not reference the parent NFT1 Group token. The group is
+// identified by the first input of the child GENESIS transaction, which must
+// spend an NFT1 Group output, so callers relating a child to its group need to
+// look up the token held by that input.
+func (r *ParseResult) IsNft1ChildGenesis() bool {
+	return r.TokenType == 0x41 && r.TransactionType == "GENESIS"
+}
+
+// AsGenesis returns the genesis message of the result, and false if the
+// result is not a GENESIS
+func (r *ParseResult) AsGenesis() (*SlpGenesis, bool) {
+	g, ok := r.Data.(*SlpGenesis)
+	return g, ok
+}
 
-	extractU8 := func() int {
-		r := uint8(itObj[it : it+1][0])
-		it++
-		return int(r)
+// AsMint returns the mint message of the result, and false if the result is
+// not a MINT
+func (r *ParseResult) AsMint() (*SlpMint, bool) {
+	m, ok := r.Data.(*SlpMint)
+	return m, ok
+}
+
+// AsSend returns the send message of the result, and false if the result is
+// not a SEND
+func (r *ParseResult) AsSend() (*SlpSend, bool) {
+	s, ok := r.Data.(*SlpSend)
+	return s, ok
+}
+
+// SemanticEqual returns true if both results hold the same token type,
+// transaction type, and message, ignoring how the script was encoded, such
+// as whether the token type was pushed as 1 or 2 bytes
+func (r *ParseResult) SemanticEqual(o *ParseResult) bool {
+	if r == nil || o == nil {
+		return r == o
 	}
 
-	extractU16 := func(littleEndian bool) int {
-		var r uint16
-		if littleEndian {
-			r = binary.LittleEndian.Uint16(itObj[it : it+2])
-		} else {
-			r = binary.BigEndian.Uint16(itObj[it : it+2])
-		}
-		it += 2
-		return int(r)
+	if r.TokenType != o.TokenType || r.TransactionType != o.TransactionType {
+		return false
 	}
 
-	extractU32 := func(littleEndian bool) int {
-		var r uint32
-		if littleEndian {
-			r = binary.LittleEndian.Uint32(itObj[it : it+4])
-		} else {
-			r = binary.BigEndian.Uint32(itObj[it : it+4])
-		}
-		it += 4
-		return int(r)
+	switch data := r.Data.(type) {
+	case *SlpGenesis:
+		od, ok := o.Data.(*SlpGenesis)
+		return ok && data.Equal(*od)
+	case *SlpMint:
+		od, ok := o.Data.(*SlpMint)
+		return ok && data.Equal(*od)
+	case *SlpSend:
+		od, ok := o.Data.(*SlpSend)
+		return ok && data.Equal(*od)
 	}
 
-	extractU64 := func(littleEndian bool) int {
-		var r uint64
-		if littleEndian {
-			r = binary.LittleEndian.Uint64(itObj[it : it+8])
-		} else {
-			r = binary.BigEndian.Uint64(itObj[it : it+8])
-		}
-		return int(r)
+	return false
+}
+
+// Category returns a coarse category of the result for grouping transactions
+// in a UI: "genesis", "mint", or "send", prefixed with "nft-" for NFT1 Child
+// tokens and "nft-group-" for NFT1 Group tokens. An empty string is returned
+// for an unknown transaction type.
+func (r *ParseResult) Category() string {
+	var category string
+	switch r.TransactionType {
+	case "GENESIS":
+		category = "genesis"
+	case "MINT":
+		category = "mint"
+	case "SEND":
+		category = "send"
+	default:
+		return ""
 	}
 
-	if err := parseCheck(len(itObj) == 0, "scriptpubkey cannot be empty"); err != nil {
-		return nil, err
+	switch r.TokenType {
+	case 0x41:
+		return "nft-" + category
+	case 0x81:
+		return "nft-group-" + category
 	}
 
-	if err := parseCheck(int(itObj[it]) != OP_RETURN, "scriptpubkey not op_return"); err != nil {
-		return nil, err
+	return category
+}
+
+// TokenTypeName returns a label for the token type for display and logging:
+// "Type1", "NFT1 Group", "NFT1 Child", or "Unknown(0xNN)"
+func (r *ParseResult) TokenTypeName() string {
+	switch r.TokenType {
+	case 0x01:
+		return "Type1"
+	case 0x81:
+		return "NFT1 Group"
+	case 0x41:
+		return "NFT1 Child"
 	}
 
-	if err := parseCheck(len(itObj) < 10, "scriptpubkey too small"); err != nil {
-		return nil, err
+	return fmt.Sprintf("Unknown(0x%02x)", r.TokenType)
+}
+
+// ParseSLP unmarshalls an SLP message from a transaction scriptPubKey.
+//
+// ParseSLP and the other parse functions keep no shared state and are safe to
+// call from multiple goroutines, including on the same scriptPubKey, as long
+// as scriptPubKey is not modified during the call. The returned result does
+// not reference scriptPubKey, so the script may be reused afterwards.
+func ParseSLP(scriptPubKey []byte) (*ParseResult, error) {
+	r, _, err := ParseSLPWithChunks(scriptPubKey)
+	return r, err
+}
+
+// ParseSLPBase64 unmarshalls an SLP message from a base64 encoded
+// transaction scriptPubKey.
+func ParseSLPBase64(s string) (*ParseResult, error) {
+	scriptPubKey, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("scriptpubkey is not valid base64: %w", err)
 	}
 
-	it++
+	return ParseSLP(scriptPubKey)
+}
 
-	extractPushdata := func() int {
-		if it == len(itObj) {
-			return -1
-		}
-		cnt := extractU8()
-		if cnt > OP_0 && cnt < OP_PUSHDATA1 {
-			if it+cnt > len(itObj) {
-				it--
-				return -1
-			}
-			return cnt
-		} else if cnt == OP_PUSHDATA1 {
-			if it+1 >= len(itObj) {
-				it--
-				return -1
-			}
-			return extractU8()
-		} else if cnt == OP_PUSHDATA2 {
-			if it+2 >= len(itObj) {
-				it--
-				return -1
-			}
-			return extractU16(true)
-		} else if cnt == OP_PUSHDATA4 {
-			if it+4 >= len(itObj) {
-				it--
-				return -1
+// MaxReaderScriptLen is the largest scriptLen accepted by ParseSLPReader. It
+// is a sanity limit for the parser rather than a consensus rule, chosen to
+// match the 10000 byte limit BCH applies when executing a script.
+const MaxReaderScriptLen = 10000
+
+// ErrScriptLenTooLarge is returned by ParseSLPReader for a scriptLen above
+// MaxReaderScriptLen
+var ErrScriptLenTooLarge = errors.New("scriptLen exceeds MaxReaderScriptLen")
+
+// ParseSLPReader reads exactly scriptLen bytes of scriptPubKey from r and
+// unmarshalls an SLP message from it. io.ErrUnexpectedEOF is returned if r
+// ends before scriptLen bytes are read. scriptLen is usually read from
+// untrusted input, so a scriptLen above MaxReaderScriptLen is rejected
+// without allocating it. The script is still read from r and discarded, so
+// r is left at the end of the record.
+func ParseSLPReader(r io.Reader, scriptLen int) (*ParseResult, error) {
+	if err := parseCheck(scriptLen < 0, "scriptLen cannot be negative"); err != nil {
+		return nil, err
+	}
+
+	if scriptLen > MaxReaderScriptLen {
+		if _, err := io.CopyN(io.Discard, r, int64(scriptLen)); err != nil {
+			if err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
 			}
-			return extractU32(true)
+			return nil, err
 		}
-		// other opcodes not allowed
-		it--
-		return -1
+		return nil, fmt.Errorf("%w: got %d", ErrScriptLenTooLarge, scriptLen)
 	}
 
-	bufferToBN := func() (int, error) {
-		if len(itObj) == 1 {
-			return extractU8(), nil
-		}
-		if len(itObj) == 2 {
-			return extractU16(false), nil
-		}
-		if len(itObj) == 4 {
-			return extractU32(false), nil
-		}
-		if len(itObj) == 8 {
-			return extractU64(false), nil
+	scriptPubKey := make([]byte, scriptLen)
+	if _, err := io.ReadFull(r, scriptPubKey); err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
 		}
-		return 0, errors.New("extraction of number from buffer failed")
+		return nil, err
 	}
 
-	checkValidTokenID := func(tokenID []byte) bool {
-		return len(tokenID) == 32
+	return ParseSLP(scriptPubKey)
+}
+
+// ParseSLPWithChunks unmarshalls an SLP message from a transaction scriptPubKey
+// and also returns the pushdata chunks extracted from the script. If parsing
+// fails the chunks extracted up to the point of failure are returned along
+// with the error, which is useful when debugging non-conforming messages.
+func ParseSLPWithChunks(scriptPubKey []byte) (*ParseResult, [][]byte, error) {
+	return parseSLP(scriptPubKey, ParseOptions{})
+}
+
+// ParseSLPWithOptions unmarshalls an SLP message from a transaction
+// scriptPubKey, applying the restrictions given in opts.
+func ParseSLPWithOptions(scriptPubKey []byte, opts ParseOptions) (*ParseResult, error) {
+	r, _, err := parseSLP(scriptPubKey, opts)
+	return r, err
+}
+
+// IsCanonical returns true if scriptPubKey is a valid SLP message in which
+// every chunk is pushed using the smallest possible opcode. An error is
+// returned if scriptPubKey is not a valid SLP message for any other reason,
+// including trailing data.
+func IsCanonical(scriptPubKey []byte) (bool, error) {
+	_, err := ParseSLPWithOptions(scriptPubKey, ParseOptions{RequireMinimalPush: true})
+	if errors.Is(err, ErrNonMinimalPush) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
 	}
 
-	chunks := make([][]byte, 0)
-	for _len := extractPushdata(); _len >= 0; _len = extractPushdata() {
-		buf := make([]byte, _len)
-		copy(buf, itObj[it:it+_len])
+	return true, nil
+}
 
-		if err := parseCheck(it+_len > len(itObj), "pushdata data extraction failed"); err != nil {
-			return nil, err
-		}
+// CanonicalizeScript parses an SLP message and re-encodes it with every chunk
+// pushed using the smallest possible opcode. The token type keeps its 1 or 2
+// byte encoding. For a valid script, bytes.Equal(scriptPubKey, canonical)
+// is the same as IsCanonical.
+func CanonicalizeScript(scriptPubKey []byte) ([]byte, error) {
+	r, err := ParseSLP(scriptPubKey)
+	if err != nil {
+		return nil, err
+	}
 
-		it += _len
-		chunks = append(chunks, buf)
-		if len(chunks) == 1 {
-			lokadID := chunks[0]
+	return r.Encode()
+}
 
-			if err := parseCheck(len(lokadID) != 4, "lokad id wrong size"); err != nil {
-				return nil, err
-			}
+func parseSLP(scriptPubKey []byte, opts ParseOptions) (*ParseResult, [][]byte, error) {
+	r, chunks, err := parseScript(scriptPubKey, opts)
+	if opts.OnResult != nil {
+		opts.OnResult(chunksTransactionType(chunks), err)
+	}
+	return r, chunks, err
+}
 
-			if err := parseCheck(
-				string(lokadID[0]) != "S" ||
-					string(lokadID[1]) != "L" ||
-					string(lokadID[2]) != "P" ||
-					lokadID[3] != 0x00, "SLP not in first chunk",
-			); err != nil {
-				return nil, err
-			}
+// chunksTransactionType returns the transaction type found in the extracted
+// chunks, or an empty TransactionType if it is missing or unknown
+func chunksTransactionType(chunks [][]byte) TransactionType {
+	if len(chunks) < 3 {
+		return ""
+	}
+
+	switch t := TransactionType(chunks[2]); t {
+	case TransactionTypeGenesis, TransactionTypeMint, TransactionTypeSend:
+		return t
+	}
+
+	return ""
+}
 
+func parseScript(scriptPubKey []byte, opts ParseOptions) (*ParseResult, [][]byte, error) {
+	codec := opts.codec()
+
+	// most scripts are not SLP, so check the lokad id before decoding the
+	// rest of the script
+	if first, err := codec.FirstChunk(scriptPubKey); err == nil && !bytes.Equal(first, lokadID) {
+		return nil, [][]byte{cloneBytes(first)}, chunkCheckReason(true, 0, "lokad_id", ErrNotSLP)
+	}
+
+	chunks, decodeErr := codec.DecodeChunks(scriptPubKey)
+
+	var trailingBytes []byte
+	if opts.AllowTrailingData && decodeErr != nil {
+		var trailingErr *TrailingDataError
+		if errors.As(decodeErr, &trailingErr) {
+			trailingBytes = cloneBytes(trailingErr.Data)
+			decodeErr = nil
+		}
+	}
+	if len(chunks) > 0 {
+		if err := chunkCheckReason(!bytes.Equal(chunks[0], lokadID), 0, "lokad_id", ErrNotSLP); err != nil {
+			return nil, chunks[:1], err
 		}
 	}
 
-	if err := parseCheck(it != len(itObj), "trailing data"); err != nil {
-		return nil, err
+	if decodeErr != nil {
+		return nil, chunks, decodeErr
 	}
 
 	if err := parseCheck(len(chunks) == 0, "chunks empty"); err != nil {
-		return nil, err
+		return nil, chunks, err
+	}
+
+	if err := checkRepeatedLokad(chunks, opts); err != nil {
+		return nil, chunks, err
 	}
 
 	cit := 0
+	var itObj []byte
 
-	checkNext := func() error {
+	checkNext := func(field string) error {
 		cit++
 
-		if err := parseCheck(cit == len(chunks), "parsing ended early"); err != nil {
-			return err
+		if cit == len(chunks) {
+			return endedEarly(cit, field)
 		}
 
-		it = 0
 		itObj = chunks[cit]
 
 		return nil
 	}
 
-	if err := checkNext(); err != nil {
-		return nil, err
+	if err := checkNext("token_type"); err != nil {
+		return nil, chunks, err
 	}
 
 	tokenTypeBuf := itObj
 
-	if err := parseCheck(len(tokenTypeBuf) != 1 && len(tokenTypeBuf) != 2,
-		"token_type string length must be 1 or 2"); err != nil {
-		return nil, err
-	}
-
-	tokenType, err := bufferToBN()
+	tokenType, err := readTokenType(cit, tokenTypeBuf, opts)
 	if err != nil {
-		return nil, err
+		return nil, chunks, err
 	}
 
-	if err := parseCheck(tokenType != 0x01 &&
-		tokenType != 0x41 &&
-		tokenType != 0x81,
-		"token_type not token-type1, nft1-group, or nft1-child"); err != nil {
-		return nil, err
-	}
-
-	if err := checkNext(); err != nil {
-		return nil, err
+	if err := checkNext("transaction_type"); err != nil {
+		return nil, chunks, err
 	}
 
 	transactionType := string(itObj)
 	if transactionType == "GENESIS" {
 
-		if err := parseCheck(len(chunks) != 10, "wrong number of chunks"); err != nil {
-			return nil, err
+		if err := checkChunkCount(transactionType, len(chunks)); err != nil {
+			return nil, chunks, err
 		}
 
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkNext("ticker"); err != nil {
+			return nil, chunks, err
 		}
 
 		ticker := itObj
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkNext("name"); err != nil {
+			return nil, chunks, err
 		}
 
 		name := itObj
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkNext("document_uri"); err != nil {
+			return nil, chunks, err
 		}
 
 		documentURI := itObj
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkNext("document_hash"); err != nil {
+			return nil, chunks, err
 		}
 
 		documentHash := itObj
 
-		if err := parseCheck(len(documentHash) != 0 && len(documentHash) != 32, "documentHash must be size 0 or 32"); err != nil {
-			return nil, err
-		}
-
-		if err := checkNext(); err != nil {
-			return nil, err
-		}
-
-		decimalsBuf := itObj
-
-		if err := parseCheck(len(decimalsBuf) != 1, "decimals string length must be 1"); err != nil {
-			return nil, err
+		if err := checkDocumentHash(documentHash); err != nil {
+			return nil, chunks, err
 		}
 
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkNext("decimals"); err != nil {
+			return nil, chunks, err
 		}
 
-		decimals, err := bufferToBN()
+		decimals, err := readDecimals(cit, itObj)
 		if err != nil {
-			return nil, err
+			return nil, chunks, err
 		}
 
-		if err := parseCheck(decimals > 9, "decimals biger than 9"); err != nil {
-			return nil, err
-		}
-
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkNext("mint_baton_vout"); err != nil {
+			return nil, chunks, err
 		}
 
-		mintBatonVoutBuf := itObj
-		mintBatonVout := 0
-
-		if err := parseCheck(len(mintBatonVoutBuf) >= 2, "mintBatonVout string must be 0 or 1"); err != nil {
-			return nil, err
+		mintBatonVout, err := readMintBatonVout(cit, itObj, transactionType)
+		if err != nil {
+			return nil, chunks, err
 		}
 
-		if len(mintBatonVoutBuf) > 0 {
-			mintBatonVout, err = bufferToBN()
-			if err != nil {
-				return nil, err
-			}
-
-			if err := parseCheck(mintBatonVout < 2, "mintBatonVout must be at least 2"); err != nil {
-				return nil, err
-			}
+		if err := checkNext("initial_qty"); err != nil {
+			return nil, chunks, err
 		}
 
-		if err := checkNext(); err != nil {
-			return nil, err
+		qty, err := readAmount(cit, itObj, "initial_qty", ErrQtyLength)
+		if err != nil {
+			return nil, chunks, err
 		}
 
-		qtyBuf := itObj
-
-		if err := parseCheck(len(qtyBuf) != 8, "initialQty Must be provided as an 8-byte buffer"); err != nil {
-			return nil, err
+		genesis := SlpGenesis{
+			Ticker:        nonNilBytes(ticker),
+			Name:          nonNilBytes(name),
+			DocumentURI:   nonNilBytes(documentURI),
+			DocumentHash:  nonNilBytes(documentHash),
+			Decimals:      decimals,
+			MintBatonVout: mintBatonVout,
+			Qty:           qty,
 		}
 
-		qty, err := bufferToBN()
-		if err != nil {
-			return nil, err
+		if err := validateGenesisFields(genesis); err != nil {
+			return nil, chunks, err
 		}
 
+		var nftChildErr error
 		if tokenType == 0x41 {
-			if err := parseCheck(decimals != 0, "NFT1 child token must have divisibility set to 0 decimal places"); err != nil {
-				return nil, err
-			}
-
-			if err := parseCheck(mintBatonVout != 0, "NFT1 child token must not have a minting baton"); err != nil {
-				return nil, err
+			nftChildErr = checkNftChildGenesis(genesis)
+			if nftChildErr != nil && !opts.RelaxNftChildRules {
+				return nil, chunks, nftChildErr
 			}
+		}
 
-			if err := parseCheck(qty != 1, "NFT1 child token must have quantity of 1"); err != nil {
-				return nil, err
+		if opts.RequireValidUtf8 {
+			if err := checkGenesisUtf8(genesis); err != nil {
+				return nil, chunks, err
 			}
 		}
 
 		return &ParseResult{
 			TokenType:       tokenType,
+			TokenTypeBytes:  cloneBytes(tokenTypeBuf),
+			TrailingBytes:   trailingBytes,
 			TransactionType: transactionType,
-			Data: SlpGenesis{
-				Ticker:        ticker,
-				Name:          name,
-				DocumentURI:   documentURI,
-				DocumentHash:  documentHash,
-				Decimals:      decimals,
-				MintBatonVout: mintBatonVout,
-				Qty:           uint64(qty),
-			},
-		}, nil
+			Data:            &genesis,
+			NftChildRuleErr: nftChildErr,
+		}, chunks, nil
 	} else if transactionType == "MINT" {
 
-		if err := parseCheck(tokenType == 0x41, "NFT1 Child cannot have MINT transaction type."); err != nil {
-			return nil, err
-		}
-
-		if err := parseCheck(len(chunks) != 6, "wrong number of chunks"); err != nil {
-			return nil, err
+		if err := checkNft1ChildMint(tokenType); err != nil {
+			return nil, chunks, err
 		}
 
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkNext("token_id"); err != nil {
+			return nil, chunks, err
 		}
 
 		tokenID := itObj
 
-		if err := parseCheck(!checkValidTokenID(tokenID), "tokenID invalid size"); err != nil {
-			return nil, err
+		if err := checkTokenID(cit, tokenID); err != nil {
+			return nil, chunks, err
 		}
 
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkChunkCount(transactionType, len(chunks)); err != nil {
+			return nil, chunks, err
 		}
 
-		mintBatonVoutBuf := itObj
-		mintBatonVout := 0
-
-		if err := parseCheck(len(mintBatonVoutBuf) >= 2, "mint_baton_vout string length must be 0 or 1"); err != nil {
-			return nil, err
+		if err := checkNext("mint_baton_vout"); err != nil {
+			return nil, chunks, err
 		}
 
-		if len(mintBatonVoutBuf) > 0 {
-			mintBatonVout, err = bufferToBN()
-			if err != nil {
-				return nil, err
-			}
-
-			if err := parseCheck(mintBatonVout < 2, "mint_baton_vout must be at least 2"); err != nil {
-				return nil, err
-			}
-
+		mintBatonVout, err := readMintBatonVout(cit, itObj, transactionType)
+		if err != nil {
+			return nil, chunks, err
 		}
-		if err := checkNext(); err != nil {
-			return nil, err
+
+		if err := checkNext("additional_qty"); err != nil {
+			return nil, chunks, err
 		}
 
-		addiitionalQtyBuf := itObj
+		qty, err := readAmount(cit, itObj, "additional_qty", ErrAmountLength)
+		if err != nil {
+			return nil, chunks, err
+		}
 
-		if err := parseCheck(len(addiitionalQtyBuf) != 8, "additional_qty must be provided as an 8-byte buffer"); err != nil {
-			return nil, err
+		mint := SlpMint{
+			TokenID:       tokenID,
+			MintBatonVout: mintBatonVout,
+			Qty:           qty,
 		}
 
-		qty, err := bufferToBN()
-		if err != nil {
-			return nil, err
+		if err := ValidateMint(mint, tokenType); err != nil {
+			return nil, chunks, err
 		}
 
 		return &ParseResult{
 			TokenType:       tokenType,
+			TokenTypeBytes:  cloneBytes(tokenTypeBuf),
+			TrailingBytes:   trailingBytes,
 			TransactionType: transactionType,
-			Data: SlpMint{
-				TokenID:       tokenID,
-				MintBatonVout: mintBatonVout,
-				Qty:           uint64(qty),
-			},
-		}, nil
+			Data:            &mint,
+		}, chunks, nil
 	} else if transactionType == "SEND" {
 
-		if err := parseCheck(len(chunks) < 4, "wrong number of chunks"); err != nil {
-			return nil, err
-		}
-
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkNext("token_id"); err != nil {
+			return nil, chunks, err
 		}
 
 		tokenID := itObj
 
-		if err := parseCheck(!checkValidTokenID(tokenID), "tokenId invalid size"); err != nil {
-			return nil, err
+		if err := checkTokenID(cit, tokenID); err != nil {
+			return nil, chunks, err
 		}
 
-		if err := checkNext(); err != nil {
-			return nil, err
+		if err := checkChunkCount(transactionType, len(chunks)); err != nil {
+			return nil, chunks, err
+		}
+
+		if err := checkNext("token_amount"); err != nil {
+			return nil, chunks, err
 		}
 
 		amounts := make([]uint64, 0)
 		for cit != len(chunks) {
-			amountBuf := itObj
-
-			if err := parseCheck(len(amountBuf) != 8, "amount string size not 8 bytes"); err != nil {
-				return nil, err
-			}
-
-			value, err := bufferToBN()
+			value, err := readAmount(cit, chunks[cit], "token_amount", ErrAmountLength)
 			if err != nil {
-				return nil, err
+				return nil, chunks, err
 			}
-			amounts = append(amounts, uint64(value))
-
+			amounts = append(amounts, value)
 			cit++
-			if cit < len(chunks) {
-				itObj = chunks[cit]
-			}
-			it = 0
 		}
 
-		if err := parseCheck(len(amounts) == 0, "token_amounts size is 0"); err != nil {
-			return nil, err
+		send := SlpSend{
+			TokenID: tokenID,
+			Amounts: amounts,
 		}
 
-		if err := parseCheck(len(amounts) > 19, "token_amounts size is greater than 19"); err != nil {
-			return nil, err
+		if err := validateSend(send, opts.maxSendOutputs()); err != nil {
+			return nil, chunks, err
 		}
 
 		return &ParseResult{
 			TokenType:       tokenType,
+			TokenTypeBytes:  cloneBytes(tokenTypeBuf),
+			TrailingBytes:   trailingBytes,
 			TransactionType: transactionType,
-			Data: SlpSend{
-				TokenID: tokenID,
-				Amounts: amounts,
-			},
-		}, nil
+			Data:            &send,
+		}, chunks, nil
+	}
+
+	return nil, chunks, errors.New("impossible parsing result")
+}
+
+// checkRepeatedLokad returns ErrRepeatedLokad for a chunk after the first
+// which is the lokad id, if opts.RejectRepeatedLokad is set
+func checkRepeatedLokad(chunks [][]byte, opts ParseOptions) error {
+	if !opts.RejectRepeatedLokad {
+		return nil
+	}
+
+	for i := 1; i < len(chunks); i++ {
+		if err := chunkCheckReason(bytes.Equal(chunks[i], lokadID), i, "pushdata", ErrRepeatedLokad); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readTokenType reads a 1 or 2 byte token_type chunk and checks that the
+// token type is known and allowed by opts. 2 byte token types are read big
+// endian, normalizing 0x00NN to 0xNN.
+func readTokenType(chunkIndex int, buf []byte, opts ParseOptions) (int, error) {
+	if err := chunkCheck(len(buf) != 1 && len(buf) != 2, chunkIndex, "token_type",
+		"token_type string length must be 1 or 2"); err != nil {
+		return 0, err
+	}
+
+	tokenType := decodeTokenType(buf)
+
+	if !opts.isExtraTokenType(TokenType(tokenType)) {
+		if err := checkTokenType(tokenType); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := chunkCheckReason(!opts.tokenTypeAllowed(TokenType(tokenType)), chunkIndex, "token_type",
+		ErrTokenTypeNotAllowed); err != nil {
+		return 0, err
+	}
+
+	return tokenType, nil
+}
+
+// readDecimals reads the 1 byte decimals chunk of a GENESIS
+func readDecimals(chunkIndex int, buf []byte) (int, error) {
+	if err := chunkCheckReason(len(buf) != 1, chunkIndex, "decimals", ErrDecimalsLength); err != nil {
+		return 0, err
+	}
+
+	decimals := int(buf[0])
+	if err := checkDecimals(decimals); err != nil {
+		return 0, err
+	}
+
+	return decimals, nil
+}
+
+// readMintBatonVout reads the mint_baton_vout chunk of a GENESIS or MINT,
+// which is empty for no baton or holds a vout of at least 2
+func readMintBatonVout(chunkIndex int, buf []byte, transactionType string) (int, error) {
+	lengthMsg, minMsg := "mint_baton_vout string length must be 0 or 1", "mint_baton_vout must be at least 2"
+	if transactionType == "GENESIS" {
+		lengthMsg, minMsg = "mintBatonVout string must be 0 or 1", "mintBatonVout must be at least 2"
+	}
+
+	if err := chunkCheck(len(buf) >= 2, chunkIndex, "mint_baton_vout", lengthMsg); err != nil {
+		return 0, err
+	}
+
+	if len(buf) == 0 {
+		return 0, nil
+	}
+
+	mintBatonVout := int(buf[0])
+	if err := chunkCheck(mintBatonVout < 2, chunkIndex, "mint_baton_vout", minMsg); err != nil {
+		return 0, err
+	}
+
+	return mintBatonVout, nil
+}
+
+// readAmount reads an 8 byte big endian amount chunk, returning reason if it
+// is the wrong length
+func readAmount(chunkIndex int, buf []byte, field string, reason error) (uint64, error) {
+	if len(buf) != 8 {
+		return 0, lengthError(chunkIndex, field, reason, len(buf))
+	}
+
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+func mintBatonVoutPtr(mintBatonVout int) *int {
+	if mintBatonVout == 0 {
+		return nil
 	}
 
-	return nil, errors.New("impossible parsing result")
+	return &mintBatonVout
+}
+
+// nonNilBytes returns b, or an empty slice if b is nil
+func nonNilBytes(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+
+	return b
 }
 
 func parseCheck(v bool, str string) error {
@@ -523,3 +1141,97 @@ func parseCheck(v bool, str string) error {
 
 	return nil
 }
+
+// ParseError describes a failure to parse a specific chunk of an SLP message.
+// ChunkIndex is the index of the offending pushdata chunk, where the lokad id
+// is chunk 0, and Field is the name of the field it was expected to contain.
+// Reason can be matched using errors.Is.
+type ParseError struct {
+	ChunkIndex int
+	Field      string
+	Reason     error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("chunk %d (%s): %s", e.ChunkIndex, e.Field, e.Reason)
+}
+
+// Unwrap returns the underlying reason for the parse failure
+func (e *ParseError) Unwrap() error {
+	return e.Reason
+}
+
+func chunkCheck(v bool, chunkIndex int, field string, str string) error {
+	if v {
+		return chunkCheckReason(v, chunkIndex, field, errors.New(str))
+	}
+
+	return nil
+}
+
+// checkScriptPrefix checks that scriptPubKey is an OP_RETURN large enough to
+// hold an SLP message, returning one of the errors for non-SLP scripts
+func checkScriptPrefix(scriptPubKey []byte) error {
+	if len(scriptPubKey) == 0 {
+		return ErrEmptyScript
+	}
+
+	if err := checkEmptyFirstPush(scriptPubKey); err != nil {
+		return err
+	}
+
+	if len(scriptPubKey) < 10 {
+		return ErrTooSmall
+	}
+
+	if scriptPubKey[0] != 0x6a {
+		return ErrNotOpReturn
+	}
+
+	return nil
+}
+
+// checkEmptyFirstPush returns ErrEmptyFirstPush if the push following the
+// OP_RETURN of scriptPubKey is empty
+func checkEmptyFirstPush(scriptPubKey []byte) error {
+	if len(scriptPubKey) < 2 || scriptPubKey[0] != 0x6a {
+		return nil
+	}
+
+	if scriptPubKey[1] == 0x00 ||
+		(scriptPubKey[1] == 0x4c && len(scriptPubKey) > 2 && scriptPubKey[2] == 0x00) {
+		return ErrEmptyFirstPush
+	}
+
+	return nil
+}
+
+// lengthError returns the error for a fixed size chunk of length bytes
+func lengthError(chunkIndex int, field string, reason error, length int) error {
+	return &ParseError{
+		ChunkIndex: chunkIndex,
+		Field:      field,
+		Reason:     fmt.Errorf("%w, got %d", reason, length),
+	}
+}
+
+// endedEarly returns the error for a message missing the chunk at chunkIndex
+func endedEarly(chunkIndex int, field string) error {
+	return &ParseError{
+		ChunkIndex: chunkIndex,
+		Field:      field,
+		Reason:     fmt.Errorf("%w: expected %s", ErrParsingEndedEarly, field),
+	}
+}
+
+func chunkCheckReason(v bool, chunkIndex int, field string, reason error) error {
+	if v {
+		return &ParseError{
+			ChunkIndex: chunkIndex,
+			Field:      field,
+			Reason:     reason,
+		}
+	}
+
+	return nil
+}
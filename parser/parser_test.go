@@ -1,7 +1,1482 @@
 package parser
 
-import "testing"
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// buildScript creates an OP_RETURN scriptPubKey from a list of pushdata chunks
+func buildScript(chunks ...[]byte) []byte {
+	script := []byte{0x6a}
+	for _, chunk := range chunks {
+		switch {
+		case len(chunk) == 0:
+			script = append(script, 0x4c, 0x00)
+		case len(chunk) < 0x4c:
+			script = append(script, byte(len(chunk)))
+		default:
+			script = append(script, 0x4c, byte(len(chunk)))
+		}
+		script = append(script, chunk...)
+	}
+	return script
+}
 
 func Test1(t *testing.T) {
 
 }
+
+func TestDocumentURIIsValid(t *testing.T) {
+	tests := []struct {
+		uri   string
+		valid bool
+	}{
+		{"", false},
+		{"https://simpleledger.cash", true},
+		{"ipfs://QmXoypizjW3WknFiJnKLwHCnL72vedxjQkDDP1mXWo6uco", true},
+		{"simpleledger.cash", false},
+		{"/relative/path", false},
+		{"http://[::1", false},
+	}
+
+	for _, test := range tests {
+		g := SlpGenesis{DocumentURI: []byte(test.uri)}
+		if v := g.DocumentURIIsValid(); v != test.valid {
+			t.Errorf("DocumentURIIsValid(%q) = %v, expected %v", test.uri, v, test.valid)
+		}
+	}
+}
+
+func TestParsedDocumentURIEmpty(t *testing.T) {
+	g := SlpGenesis{}
+	if _, err := g.ParsedDocumentURI(); err != ErrEmptyDocumentURI {
+		t.Errorf("expected ErrEmptyDocumentURI, got %v", err)
+	}
+}
+
+func TestCanMintNftChildren(t *testing.T) {
+	tests := []struct {
+		tokenType int
+		expected  bool
+	}{
+		{0x01, false},
+		{0x41, false},
+		{0x81, true},
+	}
+
+	for _, test := range tests {
+		r := ParseResult{TokenType: test.tokenType}
+		if v := r.CanMintNftChildren(); v != test.expected {
+			t.Errorf("CanMintNftChildren() for token type 0x%02x = %v, expected %v", test.tokenType, v, test.expected)
+		}
+	}
+}
+
+func TestResultDataIsPointer(t *testing.T) {
+	scripts := map[string][]byte{
+		"GENESIS": buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte{}, []byte{}, []byte{}, []byte{},
+			[]byte{0x00}, []byte{}, []byte{0, 0, 0, 0, 0, 0, 0, 1}),
+		"MINT": buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("MINT"), make([]byte, 32), []byte{},
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1}),
+		"SEND": buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32),
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1}),
+	}
+
+	for txType, script := range scripts {
+		r, err := ParseSLP(script)
+		if err != nil {
+			t.Fatalf("%s: %v", txType, err)
+		}
+
+		var ok bool
+		switch txType {
+		case "GENESIS":
+			_, ok = r.Data.(*SlpGenesis)
+		case "MINT":
+			_, ok = r.Data.(*SlpMint)
+		case "SEND":
+			_, ok = r.Data.(*SlpSend)
+		}
+		if !ok {
+			t.Errorf("%s: expected pointer data, got %T", txType, r.Data)
+		}
+	}
+}
+
+func TestResultAccessors(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 7},
+	)
+
+	r, err := ParseSLP(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, ok := r.AsSend()
+	if !ok || len(s.Amounts) != 1 || s.Amounts[0] != 7 {
+		t.Errorf("expected send with amount 7, got %v %v", s, ok)
+	}
+	if _, ok := r.AsMint(); ok {
+		t.Error("expected AsMint to return false for a SEND")
+	}
+	if _, ok := r.AsGenesis(); ok {
+		t.Error("expected AsGenesis to return false for a SEND")
+	}
+
+	r = &ParseResult{TransactionType: "MINT", Data: &SlpMint{Qty: 5}}
+	if m, ok := r.AsMint(); !ok || m.Qty != 5 {
+		t.Errorf("expected mint with qty 5, got %v %v", m, ok)
+	}
+	if _, ok := r.AsSend(); ok {
+		t.Error("expected AsSend to return false for a MINT")
+	}
+
+	r = &ParseResult{TransactionType: "GENESIS", Data: &SlpGenesis{Qty: 9}}
+	if g, ok := r.AsGenesis(); !ok || g.Qty != 9 {
+		t.Errorf("expected genesis with qty 9, got %v %v", g, ok)
+	}
+}
+
+func TestSemanticEqual(t *testing.T) {
+	chunks := [][]byte{
+		[]byte("SLP\x00"),
+		{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		{0, 0, 0, 0, 0, 0, 0, 1},
+	}
+
+	oneByte, err := ParseSLP(buildScript(chunks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks[1] = []byte{0x00, 0x01}
+	twoByte, err := ParseSLP(buildScript(chunks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !oneByte.SemanticEqual(twoByte) || !twoByte.SemanticEqual(oneByte) {
+		t.Error("expected 1 and 2 byte token type encodings to be semantically equal")
+	}
+
+	chunks[4] = []byte{0, 0, 0, 0, 0, 0, 0, 2}
+	other, err := ParseSLP(buildScript(chunks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oneByte.SemanticEqual(other) {
+		t.Error("expected different amounts not to be semantically equal")
+	}
+
+	nft := oneByte.Clone()
+	nft.TokenType = 0x41
+	if oneByte.SemanticEqual(nft) {
+		t.Error("expected different token types not to be semantically equal")
+	}
+
+	var nilResult *ParseResult
+	if oneByte.SemanticEqual(nilResult) || !nilResult.SemanticEqual(nil) {
+		t.Error("expected nil results to only equal nil")
+	}
+}
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		tokenType       int
+		transactionType string
+		expected        string
+	}{
+		{0x01, "GENESIS", "genesis"},
+		{0x01, "MINT", "mint"},
+		{0x01, "SEND", "send"},
+		{0x41, "GENESIS", "nft-genesis"},
+		{0x41, "MINT", "nft-mint"},
+		{0x41, "SEND", "nft-send"},
+		{0x81, "GENESIS", "nft-group-genesis"},
+		{0x81, "MINT", "nft-group-mint"},
+		{0x81, "SEND", "nft-group-send"},
+		{0x01, "BURN", ""},
+		{0x41, "", ""},
+	}
+
+	for _, test := range tests {
+		r := ParseResult{TokenType: test.tokenType, TransactionType: test.transactionType}
+		if v := r.Category(); v != test.expected {
+			t.Errorf("Category() for token type 0x%02x %s = %q, expected %q", test.tokenType, test.transactionType, v, test.expected)
+		}
+	}
+}
+
+func TestTokenTypeName(t *testing.T) {
+	tests := []struct {
+		tokenType int
+		expected  string
+	}{
+		{0x01, "Type1"},
+		{0x81, "NFT1 Group"},
+		{0x41, "NFT1 Child"},
+		{0x02, "Unknown(0x02)"},
+		{0x1234, "Unknown(0x1234)"},
+	}
+
+	for _, test := range tests {
+		r := ParseResult{TokenType: test.tokenType}
+		if v := r.TokenTypeName(); v != test.expected {
+			t.Errorf("TokenTypeName() for token type 0x%02x = %q, expected %q", test.tokenType, v, test.expected)
+		}
+	}
+}
+
+func BenchmarkParseSLPNonSlpLokad(b *testing.B) {
+	script := buildScript(
+		[]byte("SLQ\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+	)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseSLP(script); err == nil {
+			b.Fatal("expected non-SLP script to be rejected")
+		}
+	}
+}
+
+func TestExceedsSupply(t *testing.T) {
+	tests := []struct {
+		amounts  []uint64
+		supply   uint64
+		expected bool
+	}{
+		{[]uint64{}, 0, false},
+		{[]uint64{50, 50}, 100, false},
+		{[]uint64{50, 51}, 100, true},
+		{[]uint64{MaxSlpAmount}, MaxSlpAmount, false},
+		{[]uint64{MaxSlpAmount, 1}, MaxSlpAmount, true},
+		{[]uint64{MaxSlpAmount - 1, 2, 0}, MaxSlpAmount, true},
+	}
+
+	for _, test := range tests {
+		s := SlpSend{Amounts: test.amounts}
+		if v := s.ExceedsSupply(test.supply); v != test.expected {
+			t.Errorf("ExceedsSupply(%d) with amounts %v = %v, expected %v", test.supply, test.amounts, v, test.expected)
+		}
+	}
+}
+
+func TestSendOutputs(t *testing.T) {
+	s := SlpSend{Amounts: []uint64{10, 0, 30}}
+
+	expected := 1
+	for vout, amount := range s.Outputs() {
+		if vout != expected {
+			t.Errorf("expected vout %d, got %d", expected, vout)
+		}
+		if amount != s.Amounts[vout-1] {
+			t.Errorf("vout %d: expected amount %d, got %d", vout, s.Amounts[vout-1], amount)
+		}
+		expected++
+	}
+	if expected != len(s.Amounts)+1 {
+		t.Errorf("expected %d outputs, got %d", len(s.Amounts), expected-1)
+	}
+
+	for vout := range s.Outputs() {
+		if vout != 1 {
+			t.Errorf("expected iteration to stop after vout 1, got vout %d", vout)
+		}
+		break
+	}
+}
+
+func TestIsSingleOutput(t *testing.T) {
+	tests := []struct {
+		amounts  []uint64
+		expected bool
+	}{
+		{[]uint64{}, false},
+		{[]uint64{100}, true},
+		{[]uint64{0}, true},
+		{[]uint64{50, 50}, false},
+		{make([]uint64, 19), false},
+	}
+
+	for _, test := range tests {
+		s := SlpSend{Amounts: test.amounts}
+		if v := s.IsSingleOutput(); v != test.expected {
+			t.Errorf("IsSingleOutput() with amounts %v = %v, expected %v", test.amounts, v, test.expected)
+		}
+	}
+}
+
+func TestTrailingZerosFrom(t *testing.T) {
+	tests := []struct {
+		amounts  []uint64
+		n        int
+		expected bool
+	}{
+		{[]uint64{5, 0, 0}, 1, true},
+		{[]uint64{5, 3, 0}, 1, false},
+		{[]uint64{5, 3, 0}, 2, true},
+		{[]uint64{5, 3, 0}, 3, true},
+		{[]uint64{5, 3, 0}, 10, true},
+		{[]uint64{0, 0}, 0, true},
+		{[]uint64{5}, -1, false},
+	}
+
+	for _, test := range tests {
+		s := SlpSend{Amounts: test.amounts}
+		if v := s.TrailingZerosFrom(test.n); v != test.expected {
+			t.Errorf("TrailingZerosFrom(%d) with amounts %v = %v, expected %v", test.n, test.amounts, v, test.expected)
+		}
+	}
+}
+
+func TestAmountVectorStats(t *testing.T) {
+	tests := []struct {
+		amounts   []uint64
+		nonZero   int
+		firstZero int
+	}{
+		{[]uint64{5, 0, 3, 0}, 2, 1},
+		{[]uint64{0, 5}, 1, 0},
+		{[]uint64{5, 3}, 2, -1},
+		{[]uint64{0, 0}, 0, 0},
+		{[]uint64{}, 0, -1},
+	}
+
+	for _, test := range tests {
+		s := SlpSend{Amounts: test.amounts}
+		if v := s.NonZeroCount(); v != test.nonZero {
+			t.Errorf("NonZeroCount() with amounts %v = %d, expected %d", test.amounts, v, test.nonZero)
+		}
+		if v := s.FirstZeroIndex(); v != test.firstZero {
+			t.Errorf("FirstZeroIndex() with amounts %v = %d, expected %d", test.amounts, v, test.firstZero)
+		}
+	}
+}
+
+func TestRecipientCount(t *testing.T) {
+	tests := []struct {
+		amounts      []uint64
+		lastIsChange bool
+		expected     int
+	}{
+		{[]uint64{100}, false, 1},
+		{[]uint64{100}, true, 0},
+		{[]uint64{10, 20, 70}, false, 3},
+		{[]uint64{10, 20, 70}, true, 2},
+		{[]uint64{}, true, 0},
+	}
+
+	for _, test := range tests {
+		s := SlpSend{Amounts: test.amounts}
+		if v := s.RecipientCount(test.lastIsChange); v != test.expected {
+			t.Errorf("RecipientCount(%v) with amounts %v = %d, expected %d", test.lastIsChange, test.amounts, v, test.expected)
+		}
+	}
+}
+
+func TestParseSLPWithChunks(t *testing.T) {
+	tokenID := make([]byte, 32)
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		tokenID,
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	r, chunks, err := ParseSLPWithChunks(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TransactionType != "SEND" {
+		t.Errorf("expected SEND, got %s", r.TransactionType)
+	}
+	if len(chunks) != 5 {
+		t.Errorf("expected 5 chunks, got %d", len(chunks))
+	}
+
+	// invalid amount size is reported along with the extracted chunks
+	script = buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		tokenID,
+		[]byte{0, 0, 1},
+	)
+
+	r, chunks, err = ParseSLPWithChunks(script)
+	if err == nil {
+		t.Fatal("expected error for invalid amount size")
+	}
+	if r != nil {
+		t.Error("expected nil result on error")
+	}
+	if len(chunks) != 5 || len(chunks[4]) != 3 {
+		t.Errorf("expected partial chunks to be returned, got %v", chunks)
+	}
+}
+
+func TestAmountLength(t *testing.T) {
+	amount := []byte{0, 0, 0, 0, 0, 0, 0, 1}
+	tests := []struct {
+		name   string
+		script []byte
+		index  int
+		field  string
+		reason string
+	}{
+		{
+			"send 7 byte amount",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), amount, amount[1:]),
+			5, "token_amount", "amount must be 8 bytes, got 7",
+		},
+		{
+			"send 0 byte amount",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), []byte{}),
+			4, "token_amount", "amount must be 8 bytes, got 0",
+		},
+		{
+			"mint 7 byte qty",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("MINT"), make([]byte, 32), []byte{}, amount[1:]),
+			5, "additional_qty", "amount must be 8 bytes, got 7",
+		},
+		{
+			"mint 0 byte qty",
+			buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("MINT"), make([]byte, 32), []byte{}, []byte{}),
+			5, "additional_qty", "amount must be 8 bytes, got 0",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSLP(test.script)
+
+		var parseErr *ParseError
+		if !errors.Is(err, ErrAmountLength) || !errors.As(err, &parseErr) {
+			t.Errorf("%s: expected ErrAmountLength, got %v", test.name, err)
+			continue
+		}
+		if parseErr.ChunkIndex != test.index || parseErr.Field != test.field || parseErr.Reason.Error() != test.reason {
+			t.Errorf("%s: expected chunk %d (%s): %s, got %v", test.name, test.index, test.field, test.reason, err)
+		}
+	}
+}
+
+func TestParseErrorChunkIndex(t *testing.T) {
+	amount := []byte{0, 0, 0, 0, 0, 0, 0, 1}
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		amount,
+		amount,
+		amount,
+		amount,
+		[]byte{0, 1},
+		amount,
+	)
+
+	_, err := ParseSLP(script)
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	if parseErr.ChunkIndex != 8 {
+		t.Errorf("expected chunk index 8, got %d", parseErr.ChunkIndex)
+	}
+	if parseErr.Field != "token_amount" {
+		t.Errorf("expected field token_amount, got %s", parseErr.Field)
+	}
+	if !errors.Is(err, parseErr.Reason) {
+		t.Error("expected errors.Is to match the underlying reason")
+	}
+}
+
+func TestTokenIDCheckedFirst(t *testing.T) {
+	shortID := make([]byte, 31)
+	scripts := map[string][]byte{
+		"mint with bad baton": buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("MINT"), shortID,
+			[]byte{0x01}, []byte{0, 0, 0, 0, 0, 0, 0, 1}),
+		"send with bad amount": buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), shortID,
+			[]byte{0, 1}),
+	}
+
+	for name, script := range scripts {
+		var parseErr *ParseError
+		if _, err := ParseSLP(script); !errors.As(err, &parseErr) || parseErr.ChunkIndex != 3 || parseErr.Field != "token_id" {
+			t.Errorf("%s: expected chunk 3 (token_id) error, got %v", name, err)
+		}
+	}
+
+	// the parser and the validators share one token_id check
+	_, parseErr := ParseSLP(scripts["send with bad amount"])
+	mintErr := ValidateMint(SlpMint{TokenID: shortID, MintBatonVout: 2, Qty: 1}, 0x01)
+	sendErr := ValidateSend(SlpSend{TokenID: shortID, Amounts: []uint64{1}})
+	if parseErr == nil || mintErr == nil || sendErr == nil ||
+		parseErr.Error() != mintErr.Error() || parseErr.Error() != sendErr.Error() {
+		t.Errorf("expected matching token_id errors, got %v, %v, and %v", parseErr, mintErr, sendErr)
+	}
+}
+
+func TestGenesisEqual(t *testing.T) {
+	a := SlpGenesis{
+		Ticker:        []byte("TOK"),
+		Name:          []byte("Token"),
+		DocumentURI:   nil,
+		DocumentHash:  []byte{},
+		Decimals:      2,
+		MintBatonVout: 2,
+		Qty:           1000,
+	}
+
+	b := a
+	b.DocumentURI = []byte{}
+	b.DocumentHash = nil
+	if !a.Equal(b) {
+		t.Error("expected nil and empty slices to be equal")
+	}
+
+	c := a
+	c.Ticker = []byte("TOL")
+	if a.Equal(c) {
+		t.Error("expected different tickers to be unequal")
+	}
+
+	d := a
+	d.Qty = 1001
+	if a.Equal(d) {
+		t.Error("expected different quantities to be unequal")
+	}
+}
+
+func TestGenesisNewMint(t *testing.T) {
+	tokenID := bytes.Repeat([]byte{0xab}, 32)
+
+	g := SlpGenesis{MintBatonVout: 2, Qty: 100}
+	m, err := g.NewMint(tokenID, 50, 3)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !m.Equal(SlpMint{TokenID: tokenID, MintBatonVout: 3, Qty: 50}) {
+		t.Errorf("unexpected mint %+v", m)
+	}
+	if _, err := EncodeMint(m, 0x01); err != nil {
+		t.Errorf("unexpected error encoding mint: %v", err)
+	}
+
+	tokenID[0] = 0
+	if m.TokenID[0] != 0xab {
+		t.Error("expected mint tokenID to be a copy")
+	}
+
+	if _, err := g.NewMint(tokenID, 50, 0); err != nil {
+		t.Errorf("ending minting: unexpected error %v", err)
+	}
+	if _, err := g.NewMint(tokenID[:31], 50, 2); err == nil {
+		t.Error("expected error for short tokenID")
+	}
+	if _, err := g.NewMint(tokenID, 50, 1); err == nil {
+		t.Error("expected error for baton at vout 1")
+	}
+
+	g.MintBatonVout = 0
+	if _, err := g.NewMint(tokenID, 50, 2); err != ErrNoBaton {
+		t.Errorf("expected ErrNoBaton, got %v", err)
+	}
+}
+
+func TestMintEqual(t *testing.T) {
+	a := SlpMint{TokenID: make([]byte, 32), MintBatonVout: 2, Qty: 100}
+
+	b := a
+	b.TokenID = make([]byte, 32)
+	if !a.Equal(b) {
+		t.Error("expected equal mints")
+	}
+
+	c := a
+	c.MintBatonVout = 0
+	if a.Equal(c) {
+		t.Error("expected different baton vouts to be unequal")
+	}
+
+	if !(&SlpMint{}).Equal(SlpMint{TokenID: []byte{}}) {
+		t.Error("expected nil and empty token ids to be equal")
+	}
+}
+
+func TestApplyToSupply(t *testing.T) {
+	tests := []struct {
+		prior    uint64
+		qty      uint64
+		expected uint64
+		err      error
+	}{
+		{0, 100, 100, nil},
+		{100, 0, 100, nil},
+		{MaxSlpAmount - 1, 1, MaxSlpAmount, nil},
+		{MaxSlpAmount, 0, MaxSlpAmount, nil},
+		{MaxSlpAmount - 1, 2, 0, ErrSupplyOverflow},
+		{MaxSlpAmount, MaxSlpAmount, 0, ErrSupplyOverflow},
+	}
+
+	for _, test := range tests {
+		m := SlpMint{Qty: test.qty}
+		supply, err := m.ApplyToSupply(test.prior)
+		if err != test.err || supply != test.expected {
+			t.Errorf("ApplyToSupply(%d) with qty %d = (%d, %v), expected (%d, %v)",
+				test.prior, test.qty, supply, err, test.expected, test.err)
+		}
+	}
+}
+
+func TestSendEqual(t *testing.T) {
+	a := SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{1, 2, 3}}
+
+	b := SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{1, 2, 3}}
+	if !a.Equal(b) {
+		t.Error("expected equal sends")
+	}
+
+	c := SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{1, 2}}
+	if a.Equal(c) {
+		t.Error("expected different amount counts to be unequal")
+	}
+
+	d := SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{1, 2, 4}}
+	if a.Equal(d) {
+		t.Error("expected different amounts to be unequal")
+	}
+
+	if !(&SlpSend{}).Equal(SlpSend{TokenID: []byte{}, Amounts: []uint64{}}) {
+		t.Error("expected nil and empty slices to be equal")
+	}
+}
+
+func TestGenesisEmptyFieldsNotNil(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("GENESIS"),
+		[]byte{},
+		[]byte{},
+		[]byte{},
+		[]byte{},
+		[]byte{0x08},
+		[]byte{},
+		[]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8},
+	)
+
+	r, err := ParseSLP(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := r.Data.(*SlpGenesis)
+	fields := map[string][]byte{
+		"ticker":       g.Ticker,
+		"name":         g.Name,
+		"documentURI":  g.DocumentURI,
+		"documentHash": g.DocumentHash,
+	}
+	for field, v := range fields {
+		if v == nil || len(v) != 0 {
+			t.Errorf("expected %s to be a non-nil empty slice, got %#v", field, v)
+		}
+	}
+
+	if g.Decimals != 8 {
+		t.Errorf("expected decimals 8, got %d", g.Decimals)
+	}
+	if g.MintBatonVout != 0 {
+		t.Errorf("expected no mint baton, got %d", g.MintBatonVout)
+	}
+	if g.Qty != 1000 {
+		t.Errorf("expected qty 1000, got %d", g.Qty)
+	}
+}
+
+func TestIsNft1ChildGenesis(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x41},
+		[]byte("GENESIS"),
+		[]byte("NFT"),
+		[]byte("My NFT"),
+		[]byte{},
+		[]byte{},
+		[]byte{0x00},
+		[]byte{},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	r, err := ParseSLP(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.IsNft1ChildGenesis() {
+		t.Error("expected nft1 child genesis")
+	}
+
+	r = &ParseResult{TokenType: 0x81, TransactionType: "GENESIS"}
+	if r.IsNft1ChildGenesis() {
+		t.Error("expected nft1 group genesis not to be an nft1 child genesis")
+	}
+
+	r = &ParseResult{TokenType: 0x41, TransactionType: "SEND"}
+	if r.IsNft1ChildGenesis() {
+		t.Error("expected nft1 child send not to be an nft1 child genesis")
+	}
+}
+
+func TestParseSLPReader(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	// trailing bytes after the script are left unread
+	buf := bytes.NewReader(append(script, 0xff, 0xff))
+	r, err := ParseSLPReader(buf, len(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TransactionType != "SEND" {
+		t.Errorf("expected SEND, got %s", r.TransactionType)
+	}
+	if buf.Len() != 2 {
+		t.Errorf("expected 2 unread bytes, got %d", buf.Len())
+	}
+
+	if _, err := ParseSLPReader(bytes.NewReader(script[:10]), len(script)); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF for short read, got %v", err)
+	}
+
+	if _, err := ParseSLPReader(bytes.NewReader(nil), len(script)); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF for empty reader, got %v", err)
+	}
+
+	// an oversized record is discarded, leaving the reader at the next one
+	oversized := bytes.Repeat([]byte{0x6a}, MaxReaderScriptLen+1)
+	buf = bytes.NewReader(append(oversized, script...))
+	if _, err := ParseSLPReader(buf, len(oversized)); !errors.Is(err, ErrScriptLenTooLarge) {
+		t.Errorf("expected ErrScriptLenTooLarge, got %v", err)
+	}
+	if r, err := ParseSLPReader(buf, len(script)); err != nil || r.TransactionType != "SEND" {
+		t.Errorf("expected the next record to parse, got %v", err)
+	}
+
+	if _, err := ParseSLPReader(bytes.NewReader(script), 1<<32); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF for short oversized record, got %v", err)
+	}
+
+	if _, err := ParseSLPReader(bytes.NewReader(nil), MaxReaderScriptLen); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected MaxReaderScriptLen to be accepted, got %v", err)
+	}
+}
+
+func TestLokadIDIsCopy(t *testing.T) {
+	LokadID()[0] = 'X'
+
+	if !bytes.Equal(LokadID(), []byte("SLP\x00")) {
+		t.Errorf("expected LokadID to be unchanged, got %x", LokadID())
+	}
+}
+
+func TestLokadIDMismatch(t *testing.T) {
+	for i := range LokadID() {
+		lokadID := LokadID()
+		lokadID[i]++
+
+		script := buildScript(
+			lokadID,
+			[]byte{0x01},
+			[]byte("SEND"),
+			make([]byte, 32),
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		)
+
+		_, err := ParseSLP(script)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Field != "lokad_id" {
+			t.Errorf("expected lokad_id error for prefix %q, got %v", lokadID, err)
+		}
+	}
+}
+
+func TestBigAccessors(t *testing.T) {
+	s := SlpSend{Amounts: []uint64{1, 20, 300, 4000}}
+
+	var total uint64
+	for _, amount := range s.Amounts {
+		total += amount
+	}
+
+	totalBig := new(big.Int)
+	for _, amount := range s.AmountsBig() {
+		totalBig.Add(totalBig, amount)
+	}
+
+	if !totalBig.IsUint64() || totalBig.Uint64() != total {
+		t.Errorf("expected big.Int sum %s to equal %d", totalBig, total)
+	}
+
+	// summing past uint64 does not overflow
+	s = SlpSend{Amounts: []uint64{MaxSlpAmount, MaxSlpAmount}}
+	totalBig = new(big.Int)
+	for _, amount := range s.AmountsBig() {
+		totalBig.Add(totalBig, amount)
+	}
+	expected := new(big.Int).Mul(new(big.Int).SetUint64(MaxSlpAmount), big.NewInt(2))
+	if totalBig.Cmp(expected) != 0 {
+		t.Errorf("expected %s, got %s", expected, totalBig)
+	}
+
+	g := SlpGenesis{Qty: MaxSlpAmount}
+	if g.QtyBig().Uint64() != MaxSlpAmount {
+		t.Errorf("unexpected genesis QtyBig %s", g.QtyBig())
+	}
+
+	m := SlpMint{Qty: 42}
+	if m.QtyBig().Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("unexpected mint QtyBig %s", m.QtyBig())
+	}
+}
+
+func TestGenesisDocumentHashSize(t *testing.T) {
+	tests := []struct {
+		size  int
+		valid bool
+	}{
+		{0, true},
+		{20, false},
+		{32, true},
+		{33, false},
+	}
+
+	for _, test := range tests {
+		script := buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("GENESIS"),
+			[]byte("TOK"),
+			[]byte("Token"),
+			[]byte{},
+			make([]byte, test.size),
+			[]byte{0x00},
+			[]byte{},
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		)
+
+		_, err := ParseSLP(script)
+		if test.valid && err != nil {
+			t.Errorf("documentHash size %d: unexpected error %v", test.size, err)
+		}
+		if !test.valid && !errors.Is(err, ErrDocumentHashSize) {
+			t.Errorf("documentHash size %d: expected ErrDocumentHashSize, got %v", test.size, err)
+		}
+	}
+}
+
+func TestGenesisDecimalsLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		decimals []byte
+		err      error
+	}{
+		{"1 byte", []byte{0x08}, nil},
+		{"0 bytes", []byte{}, ErrDecimalsLength},
+		{"2 bytes", []byte{0x00, 0x02}, ErrDecimalsLength},
+		{"2 bytes above 9", []byte{0x00, 0x0a}, ErrDecimalsLength},
+	}
+
+	for _, test := range tests {
+		script := buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("GENESIS"),
+			[]byte("TOK"),
+			[]byte("Token"),
+			[]byte{},
+			[]byte{},
+			test.decimals,
+			[]byte{},
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		)
+
+		_, err := ParseSLP(script)
+		if test.err == nil && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if test.err != nil && !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+
+	// a 1 byte value above 9 fails the value check instead
+	script := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte{}, []byte{}, []byte{}, []byte{},
+		[]byte{0x0a}, []byte{}, []byte{0, 0, 0, 0, 0, 0, 0, 1})
+	if _, err := ParseSLP(script); err == nil || errors.Is(err, ErrDecimalsLength) {
+		t.Errorf("decimals 10: expected value error, got %v", err)
+	}
+}
+
+// TestGenesisDecimalsBatonOffset guards against decimals being read from the
+// mint_baton_vout chunk, which once caused every GENESIS to be rejected
+func TestGenesisDecimalsBatonOffset(t *testing.T) {
+	script := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte{}, []byte{}, []byte{}, []byte{},
+		[]byte{0x03}, []byte{0x05}, []byte{0, 0, 0, 0, 0, 0, 0, 1})
+
+	r, err := ParseSLP(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, _ := r.AsGenesis()
+	if g.Decimals != 3 || g.MintBatonVout != 5 {
+		t.Errorf("expected decimals 3 and mint_baton_vout 5, got %d and %d", g.Decimals, g.MintBatonVout)
+	}
+
+	// decimals read from the baton chunk would pass, so a bad decimals chunk
+	// must be reported at chunk 7
+	script = buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte{}, []byte{}, []byte{}, []byte{},
+		[]byte{0x0a}, []byte{0x02}, []byte{0, 0, 0, 0, 0, 0, 0, 1})
+
+	var parseErr *ParseError
+	if _, err := ParseSLP(script); !errors.As(err, &parseErr) || parseErr.ChunkIndex != 7 || parseErr.Field != "decimals" {
+		t.Errorf("expected chunk 7 (decimals) error, got %v", err)
+	}
+
+	// decimals above 9 is reported at chunk 7 even when a later chunk is
+	// also malformed
+	script = buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte{}, []byte{}, []byte{}, []byte{},
+		[]byte{0x0a}, []byte{0x02}, []byte{0, 0, 1})
+
+	if _, err := ParseSLP(script); !errors.As(err, &parseErr) || parseErr.ChunkIndex != 7 || parseErr.Field != "decimals" {
+		t.Errorf("expected chunk 7 (decimals) error with a malformed initial_qty, got %v", err)
+	}
+}
+
+func TestGenesisQtyLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		qty    []byte
+		reason string
+	}{
+		{"4 bytes", []byte{0, 0, 0, 1}, "initialQty must be 8 bytes, got 4"},
+		{"0 bytes", []byte{}, "initialQty must be 8 bytes, got 0"},
+	}
+
+	for _, test := range tests {
+		script := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("GENESIS"), []byte{}, []byte{}, []byte{}, []byte{},
+			[]byte{0x00}, []byte{}, test.qty)
+
+		_, err := ParseSLP(script)
+
+		var parseErr *ParseError
+		if !errors.Is(err, ErrQtyLength) || !errors.As(err, &parseErr) {
+			t.Errorf("%s: expected ErrQtyLength, got %v", test.name, err)
+			continue
+		}
+		if parseErr.ChunkIndex != 9 || parseErr.Field != "initial_qty" || parseErr.Reason.Error() != test.reason {
+			t.Errorf("%s: expected chunk 9 (initial_qty): %s, got %v", test.name, test.reason, err)
+		}
+	}
+}
+
+func TestParseSLPBase64(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	r, err := ParseSLPBase64(base64.StdEncoding.EncodeToString(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TransactionType != "SEND" {
+		t.Errorf("expected SEND, got %s", r.TransactionType)
+	}
+
+	if _, err := ParseSLPBase64("not base64!"); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+}
+
+func TestParseSLPShortScripts(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   []byte
+		expected string
+	}{
+		{"0 bytes", []byte{}, "scriptpubkey cannot be empty"},
+		{"1 byte op_return", []byte{0x6a}, "scriptpubkey too small"},
+		{"1 byte not op_return", []byte{0x00}, "scriptpubkey too small"},
+		{"9 bytes", []byte{0x6a, 0x04, 'S', 'L', 'P', 0x00, 0x01, 0x01, 0x04}, "scriptpubkey too small"},
+		{"10 bytes not op_return", []byte{0x76, 0x04, 'S', 'L', 'P', 0x00, 0x01, 0x01, 0x01, 0x00}, "scriptpubkey not op_return"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSLP(test.script)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected error %q, got %v", test.name, test.expected, err)
+		}
+	}
+}
+
+func TestParseSLPNotSlpErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   []byte
+		expected error
+	}{
+		{"empty", []byte{}, ErrEmptyScript},
+		{"too small", []byte{0x6a, 0x04, 'S', 'L', 'P', 0x00}, ErrTooSmall},
+		{"not op_return", []byte{0x76, 0xa9, 0x14, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x88, 0xac}, ErrNotOpReturn},
+		{"empty first push", []byte{0x6a, 0x4c, 0x00, 0x04, 'S', 'L', 'P', 0x00, 0x01, 0x01}, ErrEmptyFirstPush},
+		{"other lokad id", buildScript([]byte("ABC\x00"), []byte{0x01}, []byte("SEND")), ErrNotSLP},
+		{"short lokad id", buildScript([]byte("SLP"), []byte{0x01}, []byte("SEND")), ErrNotSLP},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSLP(test.script)
+		if !errors.Is(err, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, err)
+		}
+	}
+
+	// malformed slp messages must not be classified as non-slp
+	script := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 31), make([]byte, 8))
+	_, err := ParseSLP(script)
+	if err == nil {
+		t.Fatal("expected error for malformed send")
+	}
+	for _, notSlp := range []error{ErrEmptyScript, ErrTooSmall, ErrNotOpReturn, ErrEmptyFirstPush, ErrNotSLP} {
+		if errors.Is(err, notSlp) {
+			t.Errorf("malformed send classified as %v", notSlp)
+		}
+	}
+}
+
+func TestParseSLPOpZeroPrefix(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	if _, err := ParseSLP(script); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseSLP(append([]byte{0x00}, script...)); !errors.Is(err, ErrNotOpReturn) {
+		t.Errorf("expected ErrNotOpReturn for OP_0 OP_RETURN prefix, got %v", err)
+	}
+}
+
+func TestParseSLPConcurrent(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		bytes.Repeat([]byte{0xab}, 32),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 2},
+	)
+
+	var wg sync.WaitGroup
+	results := make([]*ParseResult, 32)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := ParseSLP(script)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			results[i] = r
+		}(i)
+	}
+	wg.Wait()
+
+	expected := SlpSend{TokenID: bytes.Repeat([]byte{0xab}, 32), Amounts: []uint64{1, 2}}
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		s := r.Data.(*SlpSend)
+		if !s.Equal(expected) {
+			t.Errorf("unexpected result %v", s)
+		}
+	}
+
+	// results do not share memory with the input script
+	for i := range script {
+		script[i] = 0
+	}
+	s := results[0].Data.(*SlpSend)
+	if !s.Equal(expected) {
+		t.Error("result changed after modifying the input script")
+	}
+}
+
+func TestAmountForVout(t *testing.T) {
+	s := SlpSend{Amounts: []uint64{10, 20, 30}}
+
+	tests := []struct {
+		vout   int
+		amount uint64
+		ok     bool
+	}{
+		{-1, 0, false},
+		{0, 0, false},
+		{1, 10, true},
+		{2, 20, true},
+		{3, 30, true},
+		{4, 0, false},
+	}
+
+	for _, test := range tests {
+		amount, ok := s.AmountForVout(test.vout)
+		if amount != test.amount || ok != test.ok {
+			t.Errorf("AmountForVout(%d) = %d, %v, expected %d, %v", test.vout, amount, ok, test.amount, test.ok)
+		}
+	}
+}
+
+func TestMintBatonVoutPtr(t *testing.T) {
+	mintScript := func(baton []byte) []byte {
+		return buildScript(
+			[]byte("SLP\x00"),
+			[]byte{0x01},
+			[]byte("MINT"),
+			make([]byte, 32),
+			baton,
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		)
+	}
+
+	r, err := ParseSLP(mintScript([]byte{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.Data.(*SlpMint)
+	if m.MintBatonVout != 0 || m.MintBatonVoutPtr() != nil {
+		t.Errorf("expected no mint baton, got %d", m.MintBatonVout)
+	}
+
+	r, err = ParseSLP(mintScript([]byte{0x03}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = r.Data.(*SlpMint)
+	if m.MintBatonVout != 3 || m.MintBatonVoutPtr() == nil || *m.MintBatonVoutPtr() != 3 {
+		t.Errorf("expected mint baton at vout 3, got %d", m.MintBatonVout)
+	}
+
+	if _, err := ParseSLP(mintScript([]byte{0x00})); err == nil {
+		t.Error("expected a pushed mint baton vout of 0 to be rejected")
+	}
+
+	g := SlpGenesis{}
+	if g.MintBatonVoutPtr() != nil {
+		t.Error("expected no genesis mint baton")
+	}
+
+	g.MintBatonVout = 2
+	if p := g.MintBatonVoutPtr(); p == nil || *p != 2 {
+		t.Error("expected genesis mint baton at vout 2")
+	}
+}
+
+func TestBatonVout(t *testing.T) {
+	g := SlpGenesis{}
+	if vout, ok := g.BatonVout(); ok || vout != 0 {
+		t.Errorf("expected no genesis mint baton, got (%d, %v)", vout, ok)
+	}
+
+	g.MintBatonVout = 2
+	if vout, ok := g.BatonVout(); !ok || vout != 2 {
+		t.Errorf("expected genesis mint baton at vout 2, got (%d, %v)", vout, ok)
+	}
+
+	m := SlpMint{}
+	if vout, ok := m.BatonVout(); ok || vout != 0 {
+		t.Errorf("expected no mint baton, got (%d, %v)", vout, ok)
+	}
+
+	m.MintBatonVout = 255
+	if vout, ok := m.BatonVout(); !ok || vout != 255 {
+		t.Errorf("expected mint baton at vout 255, got (%d, %v)", vout, ok)
+	}
+}
+
+func TestOutputMap(t *testing.T) {
+	g := SlpGenesis{MintBatonVout: 2, Qty: 1000}
+	if m := g.OutputMap(); !reflect.DeepEqual(m, map[int]uint64{1: 1000}) {
+		t.Errorf("unexpected genesis output map %v", m)
+	}
+
+	mint := SlpMint{MintBatonVout: 3, Qty: 50}
+	if m := mint.OutputMap(); !reflect.DeepEqual(m, map[int]uint64{1: 50}) {
+		t.Errorf("unexpected mint output map %v", m)
+	}
+
+	s := SlpSend{Amounts: []uint64{10, 0, 30}}
+	if m := s.OutputMap(); !reflect.DeepEqual(m, map[int]uint64{1: 10, 2: 0, 3: 30}) {
+		t.Errorf("unexpected send output map %v", m)
+	}
+}
+
+func TestIsFixedSupply(t *testing.T) {
+	g := SlpGenesis{Qty: 1000}
+	if !g.IsFixedSupply() {
+		t.Error("expected genesis without mint baton to be fixed supply")
+	}
+
+	g.MintBatonVout = 2
+	if g.IsFixedSupply() {
+		t.Error("expected genesis with mint baton not to be fixed supply")
+	}
+}
+
+func TestIsForToken(t *testing.T) {
+	tokenID := bytes.Repeat([]byte{0xab}, 32)
+	other := bytes.Repeat([]byte{0xcd}, 32)
+
+	tests := []struct {
+		name     string
+		msgID    []byte
+		tokenID  []byte
+		expected bool
+	}{
+		{"matching", tokenID, tokenID, true},
+		{"different", tokenID, other, false},
+		{"short", tokenID, tokenID[:31], false},
+		{"short prefix of message", tokenID[:31], tokenID[:31], false},
+		{"both empty", []byte{}, nil, false},
+	}
+
+	for _, test := range tests {
+		m := SlpMint{TokenID: test.msgID}
+		if v := m.IsForToken(test.tokenID); v != test.expected {
+			t.Errorf("%s: mint IsForToken = %v, expected %v", test.name, v, test.expected)
+		}
+		s := SlpSend{TokenID: test.msgID}
+		if v := s.IsForToken(test.tokenID); v != test.expected {
+			t.Errorf("%s: send IsForToken = %v, expected %v", test.name, v, test.expected)
+		}
+	}
+}
+
+func TestTokenIDFromHex(t *testing.T) {
+	s := SlpSend{TokenID: bytes.Repeat([]byte{0x01, 0xfe}, 16)}
+	tokenID, err := TokenIDFromHex(s.TokenIDAsHex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(tokenID, s.TokenID) {
+		t.Errorf("expected %x, got %x", s.TokenID, tokenID)
+	}
+
+	invalid := []string{
+		"",
+		"abc",
+		"zz" + s.TokenIDAsHex()[2:],
+		s.TokenIDAsHex()[:62],
+		s.TokenIDAsHex() + "00",
+	}
+	for _, v := range invalid {
+		if _, err := TokenIDFromHex(v); !errors.Is(err, ErrInvalidTokenID) {
+			t.Errorf("TokenIDFromHex(%q): expected ErrInvalidTokenID, got %v", v, err)
+		}
+	}
+}
+
+func TestOutputAmountSum(t *testing.T) {
+	s := SlpSend{Amounts: []uint64{MaxSlpAmount - 10, 4, 6}}
+	total, err := s.OutputAmountSum()
+	if err != nil || total != MaxSlpAmount {
+		t.Errorf("expected %d, got %d, %v", MaxSlpAmount, total, err)
+	}
+
+	s = SlpSend{Amounts: []uint64{MaxSlpAmount - 10, 4, 7}}
+	if _, err := s.OutputAmountSum(); err != ErrAmountOverflow {
+		t.Errorf("expected ErrAmountOverflow, got %v", err)
+	}
+
+	s = SlpSend{Amounts: []uint64{0, 0}}
+	if total, err := s.OutputAmountSum(); err != nil || total != 0 {
+		t.Errorf("expected 0, got %d, %v", total, err)
+	}
+}
+
+func TestParsingEndedEarlyExpectedField(t *testing.T) {
+	chunks := [][]byte{
+		[]byte("SLP\x00"),
+		{0x01},
+		[]byte("GENESIS"),
+		[]byte("TOKEN"),
+		[]byte("Token"),
+		[]byte("https://simpleledger.cash"),
+		{},
+		{0x02},
+		{0x02},
+		{0, 0, 0, 0, 0, 0, 0x03, 0xe8},
+	}
+	fields := []string{
+		"lokad_id",
+		"token_type",
+		"transaction_type",
+		"ticker",
+		"name",
+		"document_uri",
+		"document_hash",
+		"decimals",
+		"mint_baton_vout",
+		"initial_qty",
+	}
+
+	if _, err := ParseSLP(buildScript(chunks...)); err != nil {
+		t.Fatal(err)
+	}
+
+	// scripts with fewer than 3 chunks are rejected as too small
+	for n := 3; n < len(chunks); n++ {
+		_, err := ParseSLP(buildScript(chunks[:n]...))
+
+		var parseErr *ParseError
+		if !errors.Is(err, ErrParsingEndedEarly) || !errors.As(err, &parseErr) {
+			t.Errorf("truncated to %d chunks: expected ErrParsingEndedEarly, got %v", n, err)
+			continue
+		}
+		if parseErr.ChunkIndex != n || parseErr.Field != fields[n] {
+			t.Errorf("truncated to %d chunks: expected chunk %d (%s), got %v", n, n, fields[n], err)
+		}
+		if expected := "parsing ended early: expected " + fields[n]; parseErr.Reason.Error() != expected {
+			t.Errorf("truncated to %d chunks: expected reason %q, got %q", n, expected, parseErr.Reason)
+		}
+	}
+}
+
+func TestParseSLPEmptyFirstPush(t *testing.T) {
+	scripts := [][]byte{
+		{0x6a, 0x00},
+		{0x6a, 0x4c, 0x00},
+		append([]byte{0x6a, 0x00}, buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"))[1:]...),
+		append([]byte{0x6a, 0x4c, 0x00}, buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"))[1:]...),
+	}
+
+	for _, script := range scripts {
+		if _, err := ParseSLP(script); err != ErrEmptyFirstPush {
+			t.Errorf("%x: expected ErrEmptyFirstPush, got %v", script, err)
+		}
+	}
+
+	// an op_return without any pushes is reported differently
+	if _, err := ParseSLP([]byte{0x6a, 0x6a, 0, 0, 0, 0, 0, 0, 0, 0}); err == nil || err == ErrEmptyFirstPush {
+		t.Errorf("expected chunks empty error, got %v", err)
+	}
+}
+
+func TestHasConsistentDocument(t *testing.T) {
+	uri := []byte("https://simpleledger.cash")
+	hash := make([]byte, 32)
+
+	tests := []struct {
+		uri        []byte
+		hash       []byte
+		consistent bool
+	}{
+		{nil, nil, true},
+		{nil, hash, true},
+		{uri, nil, false},
+		{uri, hash, true},
+	}
+
+	for _, test := range tests {
+		g := SlpGenesis{DocumentURI: test.uri, DocumentHash: test.hash}
+		if v := g.HasConsistentDocument(); v != test.consistent {
+			t.Errorf("HasConsistentDocument() with uri %q and hash %x = %v, expected %v", test.uri, test.hash, v, test.consistent)
+		}
+	}
+}
+
+func TestParseSendAmountCountBoundary(t *testing.T) {
+	amount := []byte{0, 0, 0, 0, 0, 0, 0, 1}
+	tokenID := make([]byte, 32)
+
+	if _, err := ParseSLP(buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), tokenID, amount)); err != nil {
+		t.Errorf("single amount: unexpected error %v", err)
+	}
+
+	// too few chunks for a SEND
+	_, err := ParseSLP(buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND")))
+	if !errors.Is(err, ErrParsingEndedEarly) {
+		t.Errorf("no token_id: expected ErrParsingEndedEarly, got %v", err)
+	}
+
+	_, err = ParseSLP(buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), tokenID))
+	var parseErr *ParseError
+	if !errors.Is(err, ErrNoSendAmounts) || !errors.As(err, &parseErr) {
+		t.Fatalf("no amounts: expected ErrNoSendAmounts, got %v", err)
+	}
+	if parseErr.ChunkIndex != 4 || parseErr.Field != "token_amount" {
+		t.Errorf("no amounts: expected chunk 4 (token_amount), got %v", err)
+	}
+
+	_, err = ParseSLP(buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), tokenID, []byte{}))
+	if err == nil || errors.Is(err, ErrNoSendAmounts) {
+		t.Errorf("empty amount: expected amount size error, got %v", err)
+	}
+	if !errors.As(err, &parseErr) || parseErr.ChunkIndex != 4 {
+		t.Errorf("empty amount: expected error at chunk 4, got %v", err)
+	}
+}
+
+// TestGenesisReadsDecimalsChunk checks that decimals is read from chunk 7 and
+// mint_baton_vout from chunk 8. Reading decimals from the baton chunk shifted
+// every later field, so no GENESIS could be parsed.
+func TestGenesisReadsDecimalsChunk(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("GENESIS"),
+		[]byte("TOK"),
+		[]byte("Token"),
+		[]byte{},
+		[]byte{},
+		[]byte{0x08},
+		[]byte{0x02},
+		[]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8},
+	)
+
+	if _, err := ParseSLP(script); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,231 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ValidationIssue is a single problem found by ValidateReport. ChunkIndex is
+// -1 for an issue with the script as a whole.
+type ValidationIssue struct {
+	ChunkIndex int
+	Field      string
+	Err        error
+}
+
+func (i ValidationIssue) String() string {
+	if i.ChunkIndex < 0 {
+		return i.Err.Error()
+	}
+
+	return fmt.Sprintf("chunk %d (%s): %s", i.ChunkIndex, i.Field, i.Err)
+}
+
+// ValidateReport checks scriptPubKey against the SLP rules like ParseSLP,
+// but continues after a problem to report as many independent issues as
+// possible, for debugging scripts while developing a wallet. Checks which
+// depend on a field which was found invalid are skipped. nil is returned for
+// a valid script.
+//
+// ParseSLP should be used to decide whether a script is valid, since it
+// stops at the first error.
+func ValidateReport(scriptPubKey []byte) []ValidationIssue {
+	return ValidateReportWithOptions(scriptPubKey, ParseOptions{})
+}
+
+// ValidateReportWithOptions is like ValidateReport, applying the restrictions
+// given in opts as ParseSLPWithOptions does
+func ValidateReportWithOptions(scriptPubKey []byte, opts ParseOptions) []ValidationIssue {
+	r := &reporter{}
+
+	chunks, err := opts.codec().DecodeChunks(scriptPubKey)
+	var trailingErr *TrailingDataError
+	if err != nil && !(opts.AllowTrailingData && errors.As(err, &trailingErr)) {
+		r.add(-1, "", err)
+	}
+
+	if len(chunks) == 0 || !bytes.Equal(chunks[0], lokadID) {
+		if len(chunks) > 0 || err == nil {
+			r.add(0, "lokad_id", ErrNotSLP)
+		}
+		return r.issues
+	}
+
+	r.add(-1, "", checkRepeatedLokad(chunks, opts))
+
+	tokenType := -1
+	if len(chunks) > 1 {
+		var err error
+		if tokenType, err = readTokenType(1, chunks[1], opts); err != nil {
+			tokenType = -1
+			r.add(1, "token_type", err)
+		}
+	}
+
+	if len(chunks) < 3 {
+		// the first 3 chunks are the same for every transaction type
+		r.add(-1, "", checkChunkCount("GENESIS", len(chunks)))
+		return r.issues
+	}
+
+	transactionType := string(chunks[2])
+	if _, ok := messageFields[TransactionType(transactionType)]; !ok {
+		r.add(2, "transaction_type", errors.New("unknown transaction type"))
+		return r.issues
+	}
+
+	r.add(-1, "", checkChunkCount(transactionType, len(chunks)))
+
+	// chunk returns chunk i if the script has it
+	chunk := func(i int) ([]byte, bool) {
+		if i < len(chunks) {
+			return chunks[i], true
+		}
+		return nil, false
+	}
+
+	switch transactionType {
+	case "GENESIS":
+		r.reportGenesis(chunk, tokenType, opts)
+	case "MINT":
+		r.add(2, "transaction_type", checkNft1ChildMint(tokenType))
+		r.reportMint(chunk)
+	case "SEND":
+		r.reportSend(chunks, opts)
+	}
+
+	return r.issues
+}
+
+// reporter collects the issues found by ValidateReport
+type reporter struct {
+	issues []ValidationIssue
+}
+
+// add records err, if it is not nil, taking the chunk index and field from
+// err if it is a ParseError
+func (r *reporter) add(chunkIndex int, field string, err error) {
+	if err == nil {
+		return
+	}
+
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		chunkIndex, field, err = parseErr.ChunkIndex, parseErr.Field, parseErr.Reason
+	}
+
+	r.issues = append(r.issues, ValidationIssue{ChunkIndex: chunkIndex, Field: field, Err: err})
+}
+
+// addIf records err at the given chunk if v is true
+func (r *reporter) addIf(v bool, chunkIndex int, field string, err error) {
+	if v {
+		r.add(chunkIndex, field, err)
+	}
+}
+
+// reportGenesis checks each field of a GENESIS on its own, so that one
+// invalid field does not hide the others
+func (r *reporter) reportGenesis(chunk func(int) ([]byte, bool), tokenType int, opts ParseOptions) {
+	// g holds the fields read successfully, and ok is false if any failed,
+	// in which case the checks across fields are skipped
+	g, ok := SlpGenesis{}, true
+	check := func(err error) {
+		if err != nil {
+			ok = false
+			r.add(-1, "", err)
+		}
+	}
+
+	if b, found := chunk(3); found {
+		g.Ticker = b
+	}
+	if b, found := chunk(4); found {
+		g.Name = b
+	}
+	if b, found := chunk(5); found {
+		g.DocumentURI = b
+	}
+	if b, found := chunk(6); found {
+		g.DocumentHash = b
+		check(checkDocumentHash(b))
+	}
+
+	if b, found := chunk(7); found {
+		decimals, err := readDecimals(7, b)
+		check(err)
+		g.Decimals = decimals
+	}
+
+	if b, found := chunk(8); found {
+		mintBatonVout, err := readMintBatonVout(8, b, "GENESIS")
+		check(err)
+		g.MintBatonVout = mintBatonVout
+	}
+
+	if b, found := chunk(9); found {
+		qty, err := readAmount(9, b, "initial_qty", ErrQtyLength)
+		check(err)
+		g.Qty = qty
+	} else {
+		ok = false
+	}
+
+	if !ok {
+		return
+	}
+
+	if tokenType == 0x41 && !opts.RelaxNftChildRules {
+		err := checkNftChildGenesis(g)
+		r.addIf(errors.Is(err, ErrNft1ChildDecimals), 7, "decimals", ErrNft1ChildDecimals)
+		r.addIf(errors.Is(err, ErrNft1ChildMintBaton), 8, "mint_baton_vout", ErrNft1ChildMintBaton)
+		r.addIf(errors.Is(err, ErrNft1ChildQty), 9, "initial_qty", ErrNft1ChildQty)
+	}
+
+	if opts.RequireValidUtf8 {
+		r.add(-1, "", checkGenesisUtf8(g))
+	}
+}
+
+// reportMint checks each field of a MINT on its own
+func (r *reporter) reportMint(chunk func(int) ([]byte, bool)) {
+	validTokenID := make([]byte, 32)
+
+	if b, found := chunk(3); found {
+		r.add(-1, "", ValidateMint(SlpMint{TokenID: b}, 0x01))
+	}
+
+	if b, found := chunk(4); found {
+		mintBatonVout, err := readMintBatonVout(4, b, "MINT")
+		r.add(-1, "", err)
+		if err == nil {
+			r.add(-1, "", ValidateMint(SlpMint{TokenID: validTokenID, MintBatonVout: mintBatonVout}, 0x01))
+		}
+	}
+
+	if b, found := chunk(5); found {
+		_, err := readAmount(5, b, "additional_qty", ErrAmountLength)
+		r.add(-1, "", err)
+	}
+}
+
+// reportSend checks the tokenID and each amount of a SEND on its own
+func (r *reporter) reportSend(chunks [][]byte, opts ParseOptions) {
+	if len(chunks) < 4 {
+		return
+	}
+
+	r.add(-1, "", validateSend(SlpSend{TokenID: chunks[3], Amounts: []uint64{0}}, opts.maxSendOutputs()))
+
+	for i := 4; i < len(chunks); i++ {
+		_, err := readAmount(i, chunks[i], "token_amount", ErrAmountLength)
+		r.add(-1, "", err)
+	}
+
+	if len(chunks) > 4 {
+		amounts := make([]uint64, len(chunks)-4)
+		r.add(4+opts.maxSendOutputs(), "token_amount",
+			validateSend(SlpSend{TokenID: make([]byte, 32), Amounts: amounts}, opts.maxSendOutputs()))
+	}
+}
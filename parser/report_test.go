@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestValidateReport(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("GENESIS"),
+		[]byte("TOK"),
+		[]byte("Token"),
+		[]byte{},
+		make([]byte, 31),
+		[]byte{0x0a},
+		[]byte{},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	issues := ValidateReport(script)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if issues[0].ChunkIndex != 6 || !errors.Is(issues[0].Err, ErrDocumentHashSize) {
+		t.Errorf("expected documentHash issue at chunk 6, got %s", issues[0])
+	}
+	if issues[1].ChunkIndex != 7 || issues[1].Field != "decimals" {
+		t.Errorf("expected decimals issue at chunk 7, got %s", issues[1])
+	}
+
+	// ParseSLP stops at the first
+	if _, err := ParseSLP(script); !errors.Is(err, ErrDocumentHashSize) {
+		t.Errorf("expected ParseSLP to fail with ErrDocumentHashSize, got %v", err)
+	}
+}
+
+func TestValidateReportNotSlp(t *testing.T) {
+	issues := ValidateReport(buildScript([]byte("ABC\x00"), []byte{0x01}, []byte("SEND")))
+	if len(issues) != 1 || !errors.Is(issues[0].Err, ErrNotSLP) {
+		t.Errorf("expected a single ErrNotSLP issue, got %v", issues)
+	}
+}
+
+func TestValidateReportMatchesParser(t *testing.T) {
+	for _, test := range loadScriptTests(t, "testdata/script_tests.json") {
+		script, err := hex.DecodeString(test.Script)
+		if err != nil {
+			t.Fatalf("%s: %v", test.Msg, err)
+		}
+
+		checkReportMatchesParser(t, test.Msg, script)
+
+		// every single byte change must also agree
+		for i := range script {
+			mutated := append([]byte{}, script...)
+			mutated[i] ^= 0x01
+			checkReportMatchesParser(t, test.Msg, mutated)
+		}
+	}
+}
+
+// checkReportMatchesParser checks that ValidateReport finds issues in script
+// exactly when ParseSLP rejects it
+func checkReportMatchesParser(t *testing.T, msg string, script []byte) {
+	t.Helper()
+
+	_, err := ParseSLP(script)
+	issues := ValidateReport(script)
+	if (err == nil) != (len(issues) == 0) {
+		t.Errorf("%s: %x: ParseSLP returned %v but ValidateReport found %v", msg, script, err, issues)
+	}
+}
+
+func TestValidateReportMintAndSend(t *testing.T) {
+	mint := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("MINT"),
+		make([]byte, 31),
+		[]byte{0x01},
+		[]byte{0, 0, 0, 1},
+	)
+
+	issues := ValidateReport(mint)
+	var fields []string
+	for _, issue := range issues {
+		fields = append(fields, issue.Field)
+	}
+	if len(issues) != 3 || fields[0] != "token_id" || fields[1] != "mint_baton_vout" || fields[2] != "additional_qty" {
+		t.Errorf("mint: expected token_id, mint_baton_vout, and additional_qty issues, got %v", issues)
+	}
+
+	send := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x01},
+		[]byte("SEND"),
+		make([]byte, 31),
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+		[]byte{0, 1},
+	)
+
+	issues = ValidateReport(send)
+	if len(issues) != 2 || issues[0].ChunkIndex != 3 || issues[1].ChunkIndex != 5 || !errors.Is(issues[1].Err, ErrAmountLength) {
+		t.Errorf("send: expected token_id and chunk 5 amount issues, got %v", issues)
+	}
+}
+
+func TestValidateReportNftChild(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x41},
+		[]byte("GENESIS"),
+		[]byte{},
+		[]byte{},
+		[]byte{},
+		[]byte{},
+		[]byte{0x02},
+		[]byte{0x02},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 5},
+	)
+
+	issues := ValidateReport(script)
+	expected := []error{ErrNft1ChildDecimals, ErrNft1ChildMintBaton, ErrNft1ChildQty}
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %v", len(expected), issues)
+	}
+	for i, err := range expected {
+		if issues[i].ChunkIndex != 7+i || issues[i].Err != err {
+			t.Errorf("expected %v at chunk %d, got %s", err, 7+i, issues[i])
+		}
+	}
+
+	if issues := ValidateReportWithOptions(script, ParseOptions{RelaxNftChildRules: true}); issues != nil {
+		t.Errorf("expected no issues with RelaxNftChildRules, got %v", issues)
+	}
+}
+
+func TestValidateReportMaxSendOutputs(t *testing.T) {
+	chunks := [][]byte{[]byte("SLP\x00"), {0x01}, []byte("SEND"), make([]byte, 32)}
+	for i := 0; i < 3; i++ {
+		chunks = append(chunks, []byte{0, 0, 0, 0, 0, 0, 0, 1})
+	}
+	script := buildScript(chunks...)
+
+	if issues := ValidateReport(script); issues != nil {
+		t.Errorf("expected no issues by default, got %v", issues)
+	}
+
+	opts := ParseOptions{MaxSendOutputs: 2}
+	issues := ValidateReportWithOptions(script, opts)
+	if len(issues) != 1 || !errors.Is(issues[0].Err, ErrTooManyOutputs) || issues[0].ChunkIndex != 6 {
+		t.Errorf("expected ErrTooManyOutputs at chunk 6, got %v", issues)
+	}
+	if _, err := ParseSLPWithOptions(script, opts); !errors.Is(err, ErrTooManyOutputs) {
+		t.Errorf("expected ParseSLPWithOptions to agree, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// randomBytes returns between 0 and max random bytes
+func randomBytes(r *rand.Rand, max int) []byte {
+	b := make([]byte, r.Intn(max+1))
+	r.Read(b)
+	return b
+}
+
+// randomAmount returns a random amount, favouring the boundary values
+func randomAmount(r *rand.Rand) uint64 {
+	switch r.Intn(4) {
+	case 0:
+		return 0
+	case 1:
+		return MaxSlpAmount
+	}
+	return r.Uint64()
+}
+
+// randomBatonVout returns either no mint baton or a valid baton vout
+func randomBatonVout(r *rand.Rand) int {
+	if r.Intn(2) == 0 {
+		return 0
+	}
+	return 2 + r.Intn(254)
+}
+
+type quickGenesis struct {
+	Genesis   SlpGenesis
+	TokenType int
+}
+
+func (quickGenesis) Generate(r *rand.Rand, size int) reflect.Value {
+	tokenTypes := []int{0x01, 0x41, 0x81}
+	q := quickGenesis{TokenType: tokenTypes[r.Intn(len(tokenTypes))]}
+
+	q.Genesis = SlpGenesis{
+		Ticker:        randomBytes(r, 300),
+		Name:          randomBytes(r, 300),
+		DocumentURI:   randomBytes(r, 300),
+		Decimals:      r.Intn(10),
+		MintBatonVout: randomBatonVout(r),
+		Qty:           randomAmount(r),
+	}
+	if r.Intn(2) == 0 {
+		q.Genesis.DocumentHash = make([]byte, 32)
+		r.Read(q.Genesis.DocumentHash)
+	}
+	if q.TokenType == 0x41 {
+		q.Genesis.Decimals = 0
+		q.Genesis.MintBatonVout = 0
+		q.Genesis.Qty = 1
+	}
+
+	return reflect.ValueOf(q)
+}
+
+type quickMint struct {
+	Mint      SlpMint
+	TokenType int
+}
+
+func (quickMint) Generate(r *rand.Rand, size int) reflect.Value {
+	tokenTypes := []int{0x01, 0x81}
+	q := quickMint{
+		Mint: SlpMint{
+			TokenID:       make([]byte, 32),
+			MintBatonVout: randomBatonVout(r),
+			Qty:           randomAmount(r),
+		},
+		TokenType: tokenTypes[r.Intn(len(tokenTypes))],
+	}
+	r.Read(q.Mint.TokenID)
+
+	return reflect.ValueOf(q)
+}
+
+type quickSend struct {
+	Send      SlpSend
+	TokenType int
+}
+
+func (quickSend) Generate(r *rand.Rand, size int) reflect.Value {
+	tokenTypes := []int{0x01, 0x41, 0x81}
+	q := quickSend{
+		Send: SlpSend{
+			TokenID: make([]byte, 32),
+			Amounts: make([]uint64, 1+r.Intn(DefaultMaxSendOutputs)),
+		},
+		TokenType: tokenTypes[r.Intn(len(tokenTypes))],
+	}
+	r.Read(q.Send.TokenID)
+	for i := range q.Send.Amounts {
+		q.Send.Amounts[i] = randomAmount(r)
+	}
+
+	return reflect.ValueOf(q)
+}
+
+func checkRoundTrip(t *testing.T, script []byte, err error, expected *ParseResult) bool {
+	if err != nil {
+		t.Logf("encode failed: %v", err)
+		return false
+	}
+
+	r, err := ParseSLPWithOptions(script, ParseOptions{RequireMinimalPush: true})
+	if err != nil {
+		t.Logf("parse of %x failed: %v", script, err)
+		return false
+	}
+
+	return r.SemanticEqual(expected)
+}
+
+func TestGenesisRoundTripProperty(t *testing.T) {
+	f := func(q quickGenesis) bool {
+		script, err := EncodeGenesis(q.Genesis, q.TokenType)
+		return checkRoundTrip(t, script, err, &ParseResult{
+			TokenType:       q.TokenType,
+			TransactionType: "GENESIS",
+			Data:            &q.Genesis,
+		})
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMintRoundTripProperty(t *testing.T) {
+	f := func(q quickMint) bool {
+		script, err := EncodeMint(q.Mint, q.TokenType)
+		return checkRoundTrip(t, script, err, &ParseResult{
+			TokenType:       q.TokenType,
+			TransactionType: "MINT",
+			Data:            &q.Mint,
+		})
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSendRoundTripProperty(t *testing.T) {
+	f := func(q quickSend) bool {
+		script, err := EncodeSend(q.Send, q.TokenType)
+		return checkRoundTrip(t, script, err, &ParseResult{
+			TokenType:       q.TokenType,
+			TransactionType: "SEND",
+			Data:            &q.Send,
+		})
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPanic is returned by SafeParseSLP when parsing panicked
+var ErrPanic = errors.New("parser panicked")
+
+// SafeParseSLP is ParseSLP, except that any panic while parsing is recovered
+// and returned as an error wrapping ErrPanic. ParseSLP is not expected to
+// panic on any input, so this is only a safety net for callers which cannot
+// tolerate a crash.
+func SafeParseSLP(scriptPubKey []byte) (*ParseResult, error) {
+	return safeParse(func() (*ParseResult, error) {
+		return ParseSLP(scriptPubKey)
+	})
+}
+
+// safeParse calls parse, converting a panic into an error
+func safeParse(parse func() (*ParseResult, error)) (res *ParseResult, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			res = nil
+			err = fmt.Errorf("%w: %v", ErrPanic, rec)
+		}
+	}()
+
+	return parse()
+}
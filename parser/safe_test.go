@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSafeParseSLP(t *testing.T) {
+	// truncated pushdata length bytes, which used to panic
+	scripts := [][]byte{
+		{0x6a, 0x04, 'S', 'L', 'P', 0x00, 0x01, 0x01, 0x04, 'S', 'E', 'N', 'D', 0x4d, 0x20},
+		{0x6a, 0x04, 'S', 'L', 'P', 0x00, 0x01, 0x01, 0x04, 'S', 'E', 'N', 'D', 0x4e, 0x20, 0x00},
+		{0x6a, 0x04, 'S', 'L', 'P', 0x00, 0x01, 0x01, 0x04, 'S', 'E', 'N', 'D', 0x4c},
+	}
+
+	for _, script := range scripts {
+		r, err := SafeParseSLP(script)
+		if err == nil || r != nil {
+			t.Errorf("%x: expected error, got %v", script, r)
+		}
+		if errors.Is(err, ErrPanic) {
+			t.Errorf("%x: parser panicked: %v", script, err)
+		}
+	}
+
+	script := buildScript([]byte("SLP\x00"), []byte{0x01}, []byte("SEND"), make([]byte, 32), make([]byte, 8))
+	if _, err := SafeParseSLP(script); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestSafeParseRecoversPanic(t *testing.T) {
+	r, err := safeParse(func() (*ParseResult, error) {
+		var chunks [][]byte
+		_ = chunks[1]
+		return &ParseResult{}, nil
+	})
+
+	if r != nil {
+		t.Errorf("expected nil result, got %v", r)
+	}
+	if !errors.Is(err, ErrPanic) {
+		t.Errorf("expected ErrPanic, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidateGenesis checks that a genesis message satisfies the SLP rules for
+// the given token type. The parser applies the same checks, so a message
+// built programmatically can be validated before it is encoded.
+func ValidateGenesis(g SlpGenesis, tokenType int) error {
+	if err := validateGenesisFields(g); err != nil {
+		return err
+	}
+
+	if tokenType == 0x41 {
+		return checkNftChildGenesis(g)
+	}
+
+	return nil
+}
+
+// validateGenesisFields applies the genesis checks shared by every token type
+func validateGenesisFields(g SlpGenesis) error {
+	if err := checkDocumentHash(g.DocumentHash); err != nil {
+		return err
+	}
+
+	if err := checkDecimals(g.Decimals); err != nil {
+		return err
+	}
+
+	if err := chunkCheck(g.MintBatonVout < 0 || g.MintBatonVout > 0xff, 8, "mint_baton_vout", "mintBatonVout must fit in 1 byte"); err != nil {
+		return err
+	}
+
+	if err := chunkCheck(g.MintBatonVout == 1, 8, "mint_baton_vout", "mintBatonVout must be at least 2"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkDecimals checks the range of the GENESIS decimals at chunk 7
+func checkDecimals(decimals int) error {
+	if err := chunkCheck(decimals < 0, 7, "decimals", "decimals cannot be negative"); err != nil {
+		return err
+	}
+
+	return chunkCheck(decimals > 9, 7, "decimals", "decimals biger than 9")
+}
+
+// The following errors are returned for an NFT1 Child GENESIS which breaks
+// one of the NFT1 Child rules. When more than one rule is broken they are
+// joined, so each can be matched using errors.Is.
+var (
+	// ErrNft1ChildDecimals is returned when decimals is not 0
+	ErrNft1ChildDecimals = errors.New("NFT1 child token must have divisibility set to 0 decimal places")
+
+	// ErrNft1ChildMintBaton is returned when there is a mint baton
+	ErrNft1ChildMintBaton = errors.New("NFT1 child token must not have a minting baton")
+
+	// ErrNft1ChildQty is returned when the quantity is not 1
+	ErrNft1ChildQty = errors.New("NFT1 child token must have quantity of 1")
+)
+
+// checkNftChildGenesis applies the NFT1 Child genesis rules, returning every
+// rule broken
+func checkNftChildGenesis(g SlpGenesis) error {
+	var errs []error
+	if g.Decimals != 0 {
+		errs = append(errs, ErrNft1ChildDecimals)
+	}
+
+	if g.MintBatonVout != 0 {
+		errs = append(errs, ErrNft1ChildMintBaton)
+	}
+
+	if g.Qty != 1 {
+		errs = append(errs, ErrNft1ChildQty)
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsValidNftChild returns true if the genesis satisfies the NFT1 Child rules
+// enforced by ValidateGenesis: a quantity of 1, 0 decimals, and no mint baton
+func (g *SlpGenesis) IsValidNftChild() bool {
+	return g.Qty == 1 && g.Decimals == 0 && g.MintBatonVout == 0
+}
+
+// ValidateMint checks that a mint message satisfies the SLP rules for the
+// given token type. NFT1 Child tokens cannot be minted.
+func ValidateMint(m SlpMint, tokenType int) error {
+	if err := checkNft1ChildMint(tokenType); err != nil {
+		return err
+	}
+
+	if err := checkTokenID(3, m.TokenID); err != nil {
+		return err
+	}
+
+	if err := chunkCheck(m.MintBatonVout < 0 || m.MintBatonVout > 0xff, 4, "mint_baton_vout", "mint_baton_vout must fit in 1 byte"); err != nil {
+		return err
+	}
+
+	if err := chunkCheck(m.MintBatonVout == 1, 4, "mint_baton_vout", "mint_baton_vout must be at least 2"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateSend checks that a send message satisfies the SLP rules, which
+// require a valid tokenID and between 1 and 19 amounts.
+func ValidateSend(s SlpSend) error {
+	return validateSend(s, DefaultMaxSendOutputs)
+}
+
+func validateSend(s SlpSend, maxOutputs int) error {
+	if err := checkTokenID(3, s.TokenID); err != nil {
+		return err
+	}
+
+	if err := chunkCheckReason(len(s.Amounts) == 0, 4, "token_amount", ErrNoSendAmounts); err != nil {
+		return err
+	}
+
+	if len(s.Amounts) > maxOutputs {
+		return fmt.Errorf("%w: token_amounts size is greater than %d", ErrTooManyOutputs, maxOutputs)
+	}
+
+	return nil
+}
+
+func checkTokenType(tokenType int) error {
+	return chunkCheck(tokenType != 0x01 &&
+		tokenType != 0x41 &&
+		tokenType != 0x81, 1, "token_type",
+		"token_type not token-type1, nft1-group, or nft1-child")
+}
+
+func checkNft1ChildMint(tokenType int) error {
+	if tokenType == 0x41 {
+		return ErrNft1ChildCannotMint
+	}
+
+	return nil
+}
+
+func checkGenesisUtf8(g SlpGenesis) error {
+	if err := chunkCheckReason(!g.TickerIsValidUtf8(), 3, "ticker", ErrInvalidUtf8); err != nil {
+		return err
+	}
+
+	if err := chunkCheckReason(!g.NameIsValidUtf8(), 4, "name", ErrInvalidUtf8); err != nil {
+		return err
+	}
+
+	return chunkCheckReason(!g.DocumentURIIsValidUtf8(), 5, "document_uri", ErrInvalidUtf8)
+}
+
+func checkDocumentHash(documentHash []byte) error {
+	return chunkCheckReason(len(documentHash) != 0 && len(documentHash) != 32, 6, "document_hash", ErrDocumentHashSize)
+}
+
+// checkTokenID checks the size of the token_id at chunkIndex
+func checkTokenID(chunkIndex int, tokenID []byte) error {
+	return chunkCheck(!checkValidTokenID(tokenID), chunkIndex, "token_id", "tokenID invalid size")
+}
+
+func checkValidTokenID(tokenID []byte) bool {
+	return len(tokenID) == 32
+}
@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	valid := SlpGenesis{
+		Ticker:        []byte("TOK"),
+		Name:          []byte("Token"),
+		DocumentURI:   []byte{},
+		DocumentHash:  make([]byte, 32),
+		Decimals:      2,
+		MintBatonVout: 2,
+		Qty:           1000,
+	}
+
+	nftChild := SlpGenesis{Qty: 1}
+
+	tests := []struct {
+		name      string
+		base      SlpGenesis
+		modify    func(g *SlpGenesis)
+		tokenType int
+		valid     bool
+	}{
+		{"valid", valid, func(g *SlpGenesis) {}, 0x01, true},
+		{"no document hash", valid, func(g *SlpGenesis) { g.DocumentHash = nil }, 0x01, true},
+		{"short document hash", valid, func(g *SlpGenesis) { g.DocumentHash = make([]byte, 20) }, 0x01, false},
+		{"long document hash", valid, func(g *SlpGenesis) { g.DocumentHash = make([]byte, 33) }, 0x01, false},
+		{"max decimals", valid, func(g *SlpGenesis) { g.Decimals = 9 }, 0x01, true},
+		{"decimals too large", valid, func(g *SlpGenesis) { g.Decimals = 10 }, 0x01, false},
+		{"negative decimals", valid, func(g *SlpGenesis) { g.Decimals = -1 }, 0x01, false},
+		{"no mint baton", valid, func(g *SlpGenesis) { g.MintBatonVout = 0 }, 0x01, true},
+		{"mint baton vout 1", valid, func(g *SlpGenesis) { g.MintBatonVout = 1 }, 0x01, false},
+		{"mint baton vout too large", valid, func(g *SlpGenesis) { g.MintBatonVout = 256 }, 0x01, false},
+		{"nft1 group", valid, func(g *SlpGenesis) {}, 0x81, true},
+		{"nft1 child", nftChild, func(g *SlpGenesis) {}, 0x41, true},
+		{"nft1 child with decimals", nftChild, func(g *SlpGenesis) { g.Decimals = 1 }, 0x41, false},
+		{"nft1 child with mint baton", nftChild, func(g *SlpGenesis) { g.MintBatonVout = 2 }, 0x41, false},
+		{"nft1 child with qty 2", nftChild, func(g *SlpGenesis) { g.Qty = 2 }, 0x41, false},
+	}
+
+	for _, test := range tests {
+		g := test.base
+		test.modify(&g)
+		err := ValidateGenesis(g, test.tokenType)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestValidateMint(t *testing.T) {
+	tests := []struct {
+		name      string
+		mint      SlpMint
+		tokenType int
+		valid     bool
+	}{
+		{"valid", SlpMint{TokenID: make([]byte, 32), MintBatonVout: 2, Qty: 100}, 0x01, true},
+		{"valid without baton", SlpMint{TokenID: make([]byte, 32), Qty: 100}, 0x01, true},
+		{"nft1 group", SlpMint{TokenID: make([]byte, 32), MintBatonVout: 2, Qty: 100}, 0x81, true},
+		{"nft1 child", SlpMint{TokenID: make([]byte, 32), Qty: 1}, 0x41, false},
+		{"missing token id", SlpMint{Qty: 100}, 0x01, false},
+		{"short token id", SlpMint{TokenID: make([]byte, 31), Qty: 100}, 0x01, false},
+		{"mint baton vout 1", SlpMint{TokenID: make([]byte, 32), MintBatonVout: 1}, 0x01, false},
+		{"mint baton vout too large", SlpMint{TokenID: make([]byte, 32), MintBatonVout: 256}, 0x01, false},
+	}
+
+	for _, test := range tests {
+		err := ValidateMint(test.mint, test.tokenType)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestIsValidNftChild(t *testing.T) {
+	tests := []struct {
+		name     string
+		genesis  SlpGenesis
+		expected bool
+	}{
+		{"valid", SlpGenesis{Qty: 1}, true},
+		{"qty 5", SlpGenesis{Qty: 5}, false},
+		{"qty 0", SlpGenesis{Qty: 0}, false},
+		{"decimals", SlpGenesis{Qty: 1, Decimals: 2}, false},
+		{"mint baton", SlpGenesis{Qty: 1, MintBatonVout: 2}, false},
+	}
+
+	for _, test := range tests {
+		if v := test.genesis.IsValidNftChild(); v != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, v)
+		}
+		if err := ValidateGenesis(test.genesis, 0x41); (err == nil) != test.expected {
+			t.Errorf("%s: IsValidNftChild disagrees with ValidateGenesis: %v", test.name, err)
+		}
+	}
+}
+
+func TestValidateSend(t *testing.T) {
+	tests := []struct {
+		name  string
+		send  SlpSend
+		valid bool
+	}{
+		{"valid", SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{1}}, true},
+		{"zero amount", SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{0}}, true},
+		{"19 amounts", SlpSend{TokenID: make([]byte, 32), Amounts: make([]uint64, 19)}, true},
+		{"no amounts", SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{}}, false},
+		{"20 amounts", SlpSend{TokenID: make([]byte, 32), Amounts: make([]uint64, 20)}, false},
+		{"missing token id", SlpSend{Amounts: []uint64{1}}, false},
+		{"long token id", SlpSend{TokenID: make([]byte, 33), Amounts: []uint64{1}}, false},
+	}
+
+	for _, test := range tests {
+		err := ValidateSend(test.send)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+
+	if err := ValidateSend(SlpSend{TokenID: make([]byte, 32)}); !errors.Is(err, ErrNoSendAmounts) {
+		t.Errorf("expected ErrNoSendAmounts, got %v", err)
+	}
+}
+
+func TestNft1ChildMintRejected(t *testing.T) {
+	script := buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x41},
+		[]byte("MINT"),
+		make([]byte, 32),
+		[]byte{},
+		[]byte{0, 0, 0, 0, 0, 0, 0, 1},
+	)
+
+	if _, err := ParseSLP(script); !errors.Is(err, ErrNft1ChildCannotMint) {
+		t.Errorf("expected ErrNft1ChildCannotMint, got %v", err)
+	}
+
+	// the token type is rejected before the remaining chunks are validated
+	script = buildScript(
+		[]byte("SLP\x00"),
+		[]byte{0x41},
+		[]byte("MINT"),
+		make([]byte, 3),
+	)
+
+	if _, err := ParseSLP(script); !errors.Is(err, ErrNft1ChildCannotMint) {
+		t.Errorf("expected ErrNft1ChildCannotMint for malformed mint, got %v", err)
+	}
+
+	if err := ValidateMint(SlpMint{TokenID: make([]byte, 32), Qty: 1}, 0x41); !errors.Is(err, ErrNft1ChildCannotMint) {
+		t.Errorf("expected ErrNft1ChildCannotMint from ValidateMint, got %v", err)
+	}
+}
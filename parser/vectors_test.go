@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// scriptTest is a test vector in the script_tests.json format of the SLP
+// unit test data. Code is nil for a valid script and an error code
+// otherwise. Fields holds the expected ToMap(false) of a valid script, and is
+// an extension of the upstream format.
+type scriptTest struct {
+	Msg    string            `json:"msg"`
+	Script string            `json:"script"`
+	Code   *int              `json:"code"`
+	Fields map[string]string `json:"fields"`
+}
+
+// The error codes used by testdata/script_tests.json
+const (
+	codeNotSLP           = 1
+	codeChunkCount       = 2
+	codeInvalidField     = 3
+	codeNft1Rule         = 4
+	codeUnknownTokenType = 255
+)
+
+// loadScriptTests reads test vectors in the script_tests.json format
+func loadScriptTests(t *testing.T, path string) []scriptTest {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests []scriptTest
+	if err := json.Unmarshal(data, &tests); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+
+	return tests
+}
+
+// vectorErrorCode returns the script_tests.json error code for a parse error
+func vectorErrorCode(err error) int {
+	var parseErr *ParseError
+	var trailingErr *TrailingDataError
+	switch {
+	case ClassifyError(err) == ErrorClassNotSLP,
+		errors.Is(err, ErrPushdataOverrun),
+		errors.As(err, &trailingErr):
+		return codeNotSLP
+	case errors.Is(err, ErrWrongChunkCount),
+		errors.Is(err, ErrParsingEndedEarly),
+		errors.Is(err, ErrNoSendAmounts),
+		errors.Is(err, ErrTooManyOutputs):
+		return codeChunkCount
+	case errors.Is(err, ErrNft1ChildCannotMint),
+		errors.Is(err, ErrNft1ChildDecimals),
+		errors.Is(err, ErrNft1ChildMintBaton),
+		errors.Is(err, ErrNft1ChildQty):
+		return codeNft1Rule
+	case errors.As(err, &parseErr) && parseErr.Field == "token_type":
+		return codeUnknownTokenType
+	}
+
+	return codeInvalidField
+}
+
+func TestScriptVectors(t *testing.T) {
+	for _, test := range loadScriptTests(t, "testdata/script_tests.json") {
+		script, err := hex.DecodeString(test.Script)
+		if err != nil {
+			t.Errorf("%s: invalid script hex: %v", test.Msg, err)
+			continue
+		}
+
+		r, err := ParseSLP(script)
+		if test.Code != nil {
+			if err == nil {
+				t.Errorf("%s: expected invalid (code %d)", test.Msg, *test.Code)
+			} else if code := vectorErrorCode(err); code != *test.Code {
+				t.Errorf("%s: expected code %d, got %d (%v)", test.Msg, *test.Code, code, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: expected valid, got %v", test.Msg, err)
+			continue
+		}
+		if fields := r.Data.ToMap(false); test.Fields != nil && !reflect.DeepEqual(fields, test.Fields) {
+			t.Errorf("%s: expected fields %v, got %v", test.Msg, test.Fields, fields)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package wireutil provides transaction level SLP helpers for bchd wire
+// types: parsing the SLP message of a transaction or output, finding the
+// tokenID created by a GENESIS, and checking that a transaction has the
+// outputs its SLP message refers to.
+//
+// These are kept separate from the parser package so that the parser can be
+// used without depending on a Bitcoin library. Only this package imports
+// github.com/gcash/bchd/wire.
+package wireutil
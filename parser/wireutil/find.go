@@ -0,0 +1,46 @@
+package wireutil
+
+import (
+	"bytes"
+
+	"github.com/blockparty-sh/GoSlp/parser"
+	"github.com/gcash/bchd/wire"
+)
+
+// SlpOutput is the result of parsing an SLP looking output of a transaction
+type SlpOutput struct {
+	Vout   int
+	Result *parser.ParseResult
+	Err    error
+}
+
+// FindSlpOutputs parses every output of tx which is an OP_RETURN with the SLP
+// lokad id as its first push. Per consensus only an SLP message at vout 0 is
+// valid; messages found at other vouts are ignored by validators, but finding
+// them is useful when auditing non-conforming transactions.
+func FindSlpOutputs(tx *wire.MsgTx) []SlpOutput {
+	outputs := make([]SlpOutput, 0)
+	for vout, out := range tx.TxOut {
+		if !hasSlpPrefix(out.PkScript) {
+			continue
+		}
+
+		r, err := parser.ParseSLP(out.PkScript)
+		outputs = append(outputs, SlpOutput{
+			Vout:   vout,
+			Result: r,
+			Err:    err,
+		})
+	}
+
+	return outputs
+}
+
+var lokadID = parser.LokadID()
+
+// hasSlpPrefix reports whether script is an OP_RETURN whose first push is
+// the SLP lokad id
+func hasSlpPrefix(script []byte) bool {
+	chunks, _ := parser.BCHCodec{}.DecodeChunks(script)
+	return len(chunks) > 0 && bytes.Equal(chunks[0], lokadID)
+}
@@ -0,0 +1,40 @@
+package wireutil
+
+import (
+	"testing"
+
+	"github.com/gcash/bchd/wire"
+)
+
+func TestFindSlpOutputs(t *testing.T) {
+	send := []byte{0x6a, 0x04, 'S', 'L', 'P', 0x00, 0x01, 0x01, 0x04, 'S', 'E', 'N', 'D', 0x20}
+	send = append(send, make([]byte, 32)...)
+	send = append(send, 0x08, 0, 0, 0, 0, 0, 0, 0, 1)
+
+	junk := []byte{0x6a, 0x04, 'S', 'L', 'P', 0x00, 0x01, 0x02, 0x04, 'J', 'U', 'N', 'K'}
+	other := []byte{0x6a, 0x04, 'A', 'B', 'C', 0x00, 0x01, 0x01}
+
+	tx := wire.NewMsgTx(1)
+	tx.AddTxOut(wire.NewTxOut(0, send))
+	tx.AddTxOut(wire.NewTxOut(546, []byte{0x76, 0xa9}))
+	tx.AddTxOut(wire.NewTxOut(0, junk))
+	tx.AddTxOut(wire.NewTxOut(0, other))
+
+	outputs := FindSlpOutputs(tx)
+	if len(outputs) != 2 {
+		t.Fatalf("expected 2 slp outputs, got %d", len(outputs))
+	}
+
+	if outputs[0].Vout != 0 || outputs[0].Err != nil || outputs[0].Result == nil {
+		t.Errorf("expected vout 0 to parse, got %+v", outputs[0])
+	}
+	if outputs[1].Vout != 2 || outputs[1].Err == nil || outputs[1].Result != nil {
+		t.Errorf("expected vout 2 to fail to parse, got %+v", outputs[1])
+	}
+}
+
+func TestFindSlpOutputsNone(t *testing.T) {
+	if outputs := FindSlpOutputs(newTx(3)); len(outputs) != 0 {
+		t.Errorf("expected no slp outputs, got %d", len(outputs))
+	}
+}
@@ -0,0 +1,38 @@
+package wireutil
+
+import (
+	"errors"
+
+	"github.com/blockparty-sh/GoSlp/parser"
+	"github.com/gcash/bchd/wire"
+)
+
+// ErrInsufficientOutputs is returned when a transaction does not have an
+// output for every vout referenced by its SLP message
+var ErrInsufficientOutputs = errors.New("transaction has too few outputs for slp message")
+
+// ValidateGenesisOutputs checks that tx has the outputs required by a
+// genesis message: the initial quantity at vout 1 and the mint baton, if
+// any, at g.MintBatonVout.
+func ValidateGenesisOutputs(tx *wire.MsgTx, g parser.SlpGenesis) error {
+	if len(tx.TxOut) < 2 {
+		return ErrInsufficientOutputs
+	}
+
+	if g.MintBatonVout > 0 && len(tx.TxOut) <= g.MintBatonVout {
+		return ErrInsufficientOutputs
+	}
+
+	return nil
+}
+
+// ValidateSendOutputs checks that tx has an output for every amount of a
+// send message, which requires at least len(s.Amounts)+1 outputs including
+// the OP_RETURN.
+func ValidateSendOutputs(tx *wire.MsgTx, s parser.SlpSend) error {
+	if len(tx.TxOut) < len(s.Amounts)+1 {
+		return ErrInsufficientOutputs
+	}
+
+	return nil
+}
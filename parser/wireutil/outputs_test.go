@@ -0,0 +1,61 @@
+package wireutil
+
+import (
+	"testing"
+
+	"github.com/blockparty-sh/GoSlp/parser"
+	"github.com/gcash/bchd/wire"
+)
+
+// newTx creates a transaction with n outputs
+func newTx(n int) *wire.MsgTx {
+	tx := wire.NewMsgTx(1)
+	for i := 0; i < n; i++ {
+		tx.AddTxOut(wire.NewTxOut(546, []byte{}))
+	}
+	return tx
+}
+
+func TestValidateGenesisOutputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs int
+		baton   int
+		valid   bool
+	}{
+		{"no baton", 2, 0, true},
+		{"no baton missing vout 1", 1, 0, false},
+		{"baton at vout 2", 3, 2, true},
+		{"baton at vout 3", 4, 3, true},
+		{"baton at vout 3 with 2 outputs", 2, 3, false},
+		{"baton at vout 3 with 3 outputs", 3, 3, false},
+	}
+
+	for _, test := range tests {
+		g := parser.SlpGenesis{MintBatonVout: test.baton, Qty: 1}
+		err := ValidateGenesisOutputs(newTx(test.outputs), g)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if !test.valid && err != ErrInsufficientOutputs {
+			t.Errorf("%s: expected ErrInsufficientOutputs, got %v", test.name, err)
+		}
+	}
+}
+
+func TestValidateSendOutputs(t *testing.T) {
+	s := parser.SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{1, 2, 3}}
+
+	if err := ValidateSendOutputs(newTx(2), s); err != ErrInsufficientOutputs {
+		t.Errorf("expected ErrInsufficientOutputs for 2 outputs, got %v", err)
+	}
+	if err := ValidateSendOutputs(newTx(3), s); err != ErrInsufficientOutputs {
+		t.Errorf("expected ErrInsufficientOutputs for 3 outputs, got %v", err)
+	}
+	if err := ValidateSendOutputs(newTx(4), s); err != nil {
+		t.Errorf("unexpected error for 4 outputs: %v", err)
+	}
+	if err := ValidateSendOutputs(newTx(10), s); err != nil {
+		t.Errorf("unexpected error for 10 outputs: %v", err)
+	}
+}
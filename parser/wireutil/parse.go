@@ -0,0 +1,56 @@
+package wireutil
+
+import (
+	"errors"
+
+	"github.com/blockparty-sh/GoSlp/parser"
+	"github.com/gcash/bchd/wire"
+)
+
+// ParseSLPTxOut parses the scriptPubKey of out as an SLP message. A nil out is
+// treated as an empty script.
+func ParseSLPTxOut(out *wire.TxOut) (*parser.ParseResult, error) {
+	if out == nil {
+		return parser.ParseSLP(nil)
+	}
+
+	return parser.ParseSLP(out.PkScript)
+}
+
+// ErrNoOutputs is returned for a transaction without any outputs
+var ErrNoOutputs = errors.New("transaction has no outputs")
+
+// ErrNotGenesis is returned by GenesisTokenID for a transaction which does
+// not carry a GENESIS message
+var ErrNotGenesis = errors.New("transaction is not an slp genesis")
+
+// ParseTransaction parses the SLP message of tx, which must be at vout 0
+func ParseTransaction(tx *wire.MsgTx) (*parser.ParseResult, error) {
+	if len(tx.TxOut) == 0 {
+		return nil, ErrNoOutputs
+	}
+
+	return ParseSLPTxOut(tx.TxOut[0])
+}
+
+// GenesisTokenID returns the tokenID created by a GENESIS transaction, which
+// is its txid in the byte order used by SlpMint and SlpSend. An error is
+// returned if tx does not carry a valid GENESIS message.
+func GenesisTokenID(tx *wire.MsgTx) ([]byte, error) {
+	r, err := ParseTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.TransactionType != "GENESIS" {
+		return nil, ErrNotGenesis
+	}
+
+	hash := tx.TxHash()
+	tokenID := make([]byte, len(hash))
+	for i := range hash {
+		tokenID[i] = hash[len(hash)-1-i]
+	}
+
+	return tokenID, nil
+}
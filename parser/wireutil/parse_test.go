@@ -0,0 +1,93 @@
+package wireutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blockparty-sh/GoSlp/parser"
+	"github.com/gcash/bchd/wire"
+)
+
+func TestParseSLPTxOut(t *testing.T) {
+	script, err := parser.EncodeSend(parser.SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{5}}, 0x01)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ParseSLPTxOut(wire.NewTxOut(0, script))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r.TransactionType != "SEND" {
+		t.Errorf("expected SEND, got %s", r.TransactionType)
+	}
+
+	if _, err := ParseSLPTxOut(wire.NewTxOut(546, []byte{0x76, 0xa9})); !errors.Is(err, parser.ErrTooSmall) {
+		t.Errorf("expected ErrTooSmall, got %v", err)
+	}
+
+	if _, err := ParseSLPTxOut(nil); !errors.Is(err, parser.ErrEmptyScript) {
+		t.Errorf("expected ErrEmptyScript, got %v", err)
+	}
+}
+
+func TestParseTransaction(t *testing.T) {
+	if _, err := ParseTransaction(wire.NewMsgTx(1)); err != ErrNoOutputs {
+		t.Errorf("expected ErrNoOutputs, got %v", err)
+	}
+
+	script, err := parser.EncodeSend(parser.SlpSend{TokenID: make([]byte, 32), Amounts: []uint64{5}}, 0x01)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// only vout 0 is considered
+	tx := newTx(1)
+	tx.AddTxOut(wire.NewTxOut(0, script))
+	if _, err := ParseTransaction(tx); err == nil {
+		t.Error("expected error for slp message outside vout 0")
+	}
+
+	tx = wire.NewMsgTx(1)
+	tx.AddTxOut(wire.NewTxOut(0, script))
+	tx.AddTxOut(wire.NewTxOut(546, []byte{}))
+	r, err := ParseTransaction(tx)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r.TransactionType != "SEND" {
+		t.Errorf("expected SEND, got %s", r.TransactionType)
+	}
+}
+
+func TestGenesisTokenID(t *testing.T) {
+	script, err := parser.EncodeGenesis(parser.SlpGenesis{Ticker: []byte("TOK"), Qty: 100}, 0x01)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(1)
+	tx.AddTxOut(wire.NewTxOut(0, script))
+	tx.AddTxOut(wire.NewTxOut(546, []byte{}))
+
+	tokenID, err := GenesisTokenID(tx)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// the tokenID hex matches the txid as displayed
+	send := parser.SlpSend{TokenID: tokenID}
+	if hash := tx.TxHash(); send.TokenIDAsHex() != hash.String() {
+		t.Errorf("expected tokenID %s, got %s", hash.String(), send.TokenIDAsHex())
+	}
+
+	sendScript, err := parser.EncodeSend(parser.SlpSend{TokenID: tokenID, Amounts: []uint64{100}}, 0x01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx = wire.NewMsgTx(1)
+	tx.AddTxOut(wire.NewTxOut(0, sendScript))
+	if _, err := GenesisTokenID(tx); err != ErrNotGenesis {
+		t.Errorf("expected ErrNotGenesis, got %v", err)
+	}
+}